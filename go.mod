@@ -5,6 +5,7 @@ replace github.com/pasdam/go-csv-timeseries-db/pkg => ./pkg
 go 1.17
 
 require (
+	github.com/klauspost/compress v1.15.15
 	github.com/pasdam/go-files-test v0.0.0-20200523130716-5dc6c4313161
 	github.com/pasdam/go-io-utilx v0.0.0-20201229215823-570b5ea4df86
 	github.com/pasdam/go-search v0.0.0-20201229215808-279b97b7d69a
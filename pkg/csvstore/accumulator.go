@@ -0,0 +1,72 @@
+package csvstore
+
+import (
+	"math"
+	"strconv"
+)
+
+// accumulator accumulates the values of a column to compute an aggregation
+type accumulator struct {
+	count   int
+	numeric int
+	sum     float64
+	min     float64
+	max     float64
+}
+
+// add accumulates a value
+func (a *accumulator) add(value string) {
+	if len(value) == 0 {
+		return
+	}
+	a.count++
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) {
+		return
+	}
+	a.sum += number
+	a.addRange(1, number, number)
+}
+
+// addStats accumulates the values of a column of a partition
+func (a *accumulator) addStats(stats columnStats) {
+	a.count += stats.Numeric + stats.Other
+	if stats.Numeric > 0 {
+		a.addRange(stats.Numeric, stats.Min, stats.Max)
+	}
+}
+
+func (a *accumulator) addRange(count int, min float64, max float64) {
+	if a.numeric == 0 || min < a.min {
+		a.min = min
+	}
+	if a.numeric == 0 || max > a.max {
+		a.max = max
+	}
+	a.numeric += count
+}
+
+// value returns the result of the aggregation, NaN if there are no numeric
+// values to aggregate
+func (a *accumulator) value(aggregation Aggregation) float64 {
+	if aggregation == Count {
+		return float64(a.count)
+	}
+	if a.numeric == 0 {
+		return math.NaN()
+	}
+
+	switch aggregation {
+	case Sum:
+		return a.sum
+	case Mean:
+		return a.sum / float64(a.numeric)
+	case Min:
+		return a.min
+	case Max:
+		return a.max
+	default:
+		return math.NaN()
+	}
+}
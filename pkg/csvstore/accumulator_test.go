@@ -0,0 +1,31 @@
+package csvstore
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_accumulator(t *testing.T) {
+	a := &accumulator{}
+	for _, value := range []string{"4", "", "a", "-2"} {
+		a.add(value)
+	}
+	a.addStats(columnStats{Numeric: 2, Other: 1, Min: -5, Max: 1})
+
+	assert.Equal(t, float64(6), a.value(Count))
+	assert.Equal(t, float64(2), a.value(Sum))
+	assert.Equal(t, float64(-5), a.value(Min))
+	assert.Equal(t, float64(4), a.value(Max))
+}
+
+func Test_accumulator_ShouldReturnNaNWithoutNumericValues(t *testing.T) {
+	a := &accumulator{}
+	a.add("a")
+
+	assert.Equal(t, float64(1), a.value(Count))
+	for _, aggregation := range []Aggregation{Sum, Mean, Min, Max} {
+		assert.True(t, math.IsNaN(a.value(aggregation)))
+	}
+}
@@ -0,0 +1,14 @@
+package csvstore
+
+// AllRows is the column of the Count aggregation counting the rows
+const AllRows = "*"
+
+// Aggregate is an aggregation of the values of a column
+type Aggregate struct {
+	// Func is the aggregation
+	Func Aggregation
+
+	// Column is the name or the index of the column, or AllRows to count the
+	// rows
+	Column string
+}
@@ -0,0 +1,18 @@
+package csvstore
+
+// AggregateQuery aggregates the values of the data points selected by a query
+type AggregateQuery struct {
+	// From and To are the range of the timestamps of the points
+	From uint64
+	To   uint64
+
+	// Where filters the points by the values of their columns, if not nil
+	Where Predicate
+
+	// Aggregates are the aggregations computed
+	Aggregates []Aggregate
+
+	// Step is the size of the time buckets the points are grouped by,
+	// aligned to the timestamp 0; the whole range is one bucket if 0
+	Step uint64
+}
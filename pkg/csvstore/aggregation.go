@@ -0,0 +1,48 @@
+package csvstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Aggregation is a function that aggregates the values of a column
+type Aggregation int
+
+const (
+	// Count counts the non empty values, or the rows for the column "*"
+	Count Aggregation = iota
+	// Sum sums the numeric values
+	Sum
+	// Mean averages the numeric values
+	Mean
+	// Min returns the minimum of the numeric values
+	Min
+	// Max returns the maximum of the numeric values
+	Max
+)
+
+var aggregationNames = []string{"count", "sum", "mean", "min", "max"}
+
+// String returns the name of the aggregation, e.g. "mean"
+func (a Aggregation) String() string {
+	if a < 0 || int(a) >= len(aggregationNames) {
+		return fmt.Sprintf("Aggregation(%d)", int(a))
+	}
+	return aggregationNames[a]
+}
+
+// ParseAggregation returns the aggregation with the name, case insensitive
+func ParseAggregation(name string) (Aggregation, error) {
+	for i, n := range aggregationNames {
+		if strings.EqualFold(n, name) {
+			return Aggregation(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown aggregation %q", name)
+}
+
+// fromStats returns true if the aggregation can be computed from the
+// statistics of the partitions
+func (a Aggregation) fromStats() bool {
+	return a == Count || a == Min || a == Max
+}
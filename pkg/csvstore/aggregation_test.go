@@ -0,0 +1,33 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregation_String(t *testing.T) {
+	assert.Equal(t, "mean", Mean.String())
+	assert.Equal(t, "Aggregation(10)", Aggregation(10).String())
+}
+
+func TestParseAggregation(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Aggregation
+		wantErr bool
+	}{
+		{name: "count", want: Count},
+		{name: "SUM", want: Sum},
+		{name: "Max", want: Max},
+		{name: "median", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAggregation(tt.name)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package csvstore
+
+// Append persists a single data point in the store, like StorePoints.
+// Points appended in timestamp order are written to the end of the partition
+// file, or to the write buffer if enabled, without rewriting existing data.
+func (s *Store) Append(timestamp uint64, record []string) error {
+	_, err := s.StorePoints(Points{{Timestamp: timestamp, Record: record}})
+	return err
+}
+
+// AppendBatch persists the data points in the store, like StorePoints, and
+// returns the statistics of the write.
+// Note it will sort the points before storing them.
+func (s *Store) AppendBatch(points []Point) (*WriteResult, error) {
+	return s.StorePoints(Points(points))
+}
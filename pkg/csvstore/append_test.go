@@ -0,0 +1,56 @@
+package csvstore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Append(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10)
+
+	err := s.Append(1, []string{"some-value-at-1"})
+	assert.Nil(t, err)
+	err = s.Append(3, []string{"some-value-at-3"})
+	assert.Nil(t, err)
+	err = s.Append(2, []string{"some-value-at-2"})
+	assert.Nil(t, err)
+	err = s.Append(11, []string{"some-value-at-11"})
+	assert.Nil(t, err)
+
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "1,some-value-at-1\n2,some-value-at-2\n3,some-value-at-3\n")
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "10_19.csv"), "11,some-value-at-11\n")
+}
+
+func TestStore_Append_ShouldReturnErrorIfPolicyRaisesIt(t *testing.T) {
+	s := NewStore(filestest.TempDir(t), 10, WithConflictPolicy(ErrorOnConflict))
+	err := s.Append(1, []string{"some-value-at-1"})
+	assert.Nil(t, err)
+
+	err = s.Append(1, []string{"some-other-value-at-1"})
+
+	assert.True(t, errors.Is(err, ErrConflict))
+}
+
+func TestStore_AppendBatch(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10, WithWriteBuffer(0, 0))
+
+	got, err := s.AppendBatch([]Point{
+		{Timestamp: 5, Record: []string{"some-value-at-5"}},
+		{Timestamp: 4, Record: []string{"some-value-at-4"}},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, InsertStats{Inserted: 2}, got.Buffered)
+	assert.NoFileExists(t, filepath.Join(dir, "0_9.csv"))
+
+	err = s.Close()
+
+	assert.Nil(t, err)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "4,some-value-at-4\n5,some-value-at-5\n")
+}
@@ -0,0 +1,98 @@
+package csvstore
+
+// bucketAggregator aggregates the points, received in timestamp order, by time
+// bucket, calling the handler for each bucket with at least one point
+type bucketAggregator struct {
+	from         uint64
+	step         uint64
+	signed       bool
+	aggregates   []Aggregate
+	positions    []int
+	columns      []int
+	started      bool
+	start        uint64
+	rows         int
+	accumulators []accumulator
+	handler      func(uint64, []float64) error
+}
+
+// bucket returns the start of the bucket of the timestamp
+func (b *bucketAggregator) bucket(timestamp uint64) uint64 {
+	if b.step == 0 {
+		return b.from
+	}
+	start, _ := timestampToInterval(timestamp, b.step, b.signed)
+	return start
+}
+
+// add aggregates a record, containing the columns read for the aggregates
+func (b *bucketAggregator) add(timestamp uint64, record []string) error {
+	err := b.moveTo(b.bucket(timestamp))
+	if err != nil {
+		return err
+	}
+
+	b.rows++
+	for i, position := range b.positions {
+		if position < 0 {
+			b.accumulators[i].count++
+		} else if position < len(record) {
+			b.accumulators[i].add(record[position])
+		}
+	}
+	return nil
+}
+
+// addStats aggregates all the points of a partition, from its metadata
+func (b *bucketAggregator) addStats(from uint64, meta *partitionMeta) error {
+	if meta.Rows == 0 {
+		return nil
+	}
+
+	err := b.moveTo(b.bucket(from))
+	if err != nil {
+		return err
+	}
+
+	b.rows += meta.Rows
+	for i, column := range b.columns {
+		if column < 0 {
+			b.accumulators[i].count += meta.Rows
+		} else if column < len(meta.Columns) {
+			b.accumulators[i].addStats(meta.Columns[column])
+		}
+	}
+	return nil
+}
+
+// moveTo flushes the current bucket if different from the one starting at
+// start, and starts the latter
+func (b *bucketAggregator) moveTo(start uint64) error {
+	if b.started && start == b.start {
+		return nil
+	}
+
+	err := b.flush()
+	if err != nil {
+		return err
+	}
+
+	b.started = true
+	b.start = start
+	b.rows = 0
+	b.accumulators = make([]accumulator, len(b.aggregates))
+	return nil
+}
+
+// flush calls the handler for the current bucket, if it has any point
+func (b *bucketAggregator) flush() error {
+	if !b.started || b.rows == 0 {
+		return nil
+	}
+
+	values := make([]float64, len(b.aggregates))
+	for i, a := range b.aggregates {
+		values[i] = b.accumulators[i].value(a.Func)
+	}
+	return b.handler(b.start, values)
+}
@@ -0,0 +1,28 @@
+package csvstore
+
+// bufferPoints adds the sorted points to the write buffer, and returns their
+// statistics and true if the buffer is full. The conflicts with the persisted
+// points are resolved first, so that the errors of the policy are returned
+// here, instead of failing the flush of the buffer.
+func (s *Store) bufferPoints(points dataPointList) (InsertStats, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the buffered points have already been checked
+	stored, err := s.storedStats(s.buffer.unbuffered(points), s.policy)
+	if err != nil {
+		return InsertStats{}, false, err
+	}
+
+	stats, full, err := s.buffer.add(points, s.policy)
+	if err != nil {
+		return InsertStats{}, false, err
+	}
+
+	// the points conflicting with the persisted ones are new in the buffer
+	stats.Inserted -= stored.Replaced + stored.Skipped
+	stats.Replaced += stored.Replaced
+	stats.Skipped += stored.Skipped
+
+	return stats, full, nil
+}
@@ -0,0 +1,41 @@
+package csvstore
+
+import (
+	"math"
+	"strconv"
+)
+
+// columnStats contains the statistics of the values of a column in a
+// partition
+type columnStats struct {
+	// Numeric is the number of numeric values
+	Numeric int `json:"numeric"`
+
+	// Other is the number of non empty values that are not numeric
+	Other int `json:"other,omitempty"`
+
+	// Min and Max are the minimum and maximum of the numeric values
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// add updates the statistics with a new value
+func (c *columnStats) add(value string) {
+	if len(value) == 0 {
+		return
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) {
+		c.Other++
+		return
+	}
+
+	if c.Numeric == 0 || number < c.Min {
+		c.Min = number
+	}
+	if c.Numeric == 0 || number > c.Max {
+		c.Max = number
+	}
+	c.Numeric++
+}
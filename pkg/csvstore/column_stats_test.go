@@ -0,0 +1,17 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_columnStats_add(t *testing.T) {
+	stats := &columnStats{}
+
+	for _, value := range []string{"", "3", "abc", "-1.5", "NaN", "7"} {
+		stats.add(value)
+	}
+
+	assert.Equal(t, &columnStats{Numeric: 3, Other: 2, Min: -1.5, Max: 7}, stats)
+}
@@ -0,0 +1,16 @@
+package csvstore
+
+// ColumnType is the type of the values of a column, used to compare them
+type ColumnType int
+
+const (
+	// AutoColumn compares the values as numbers if both are numeric, as
+	// strings otherwise
+	AutoColumn ColumnType = iota
+	// StringColumn compares the values as strings
+	StringColumn
+	// IntColumn compares the values as integers
+	IntColumn
+	// FloatColumn compares the values as floating point numbers
+	FloatColumn
+)
@@ -0,0 +1,290 @@
+package csvstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// columnarExtension is the extension of the partitions stored in the columnar
+// format
+const columnarExtension = ".col"
+
+// columnarMagic identifies the files in the columnar format
+const columnarMagic = "CSVCOL1\n"
+
+// ErrInvalidColumnarFile is returned when reading a columnar partition that
+// is not valid
+var ErrInvalidColumnarFile = errors.New("invalid columnar file")
+
+// The columnar format stores a partition as a header followed by a block for
+// the timestamps and one for each column:
+//
+//	magic
+//	uvarint rows
+//	uvarint columns
+//	uvarint block length (timestamps block, then each column block)
+//	timestamps block: first timestamp, then deltas from the previous one
+//	column blocks: for each row, uvarint value length + 1 (0 if the row has
+//	no such column), followed by the value
+//
+// so that a reader can skip the blocks of the columns it doesn't need.
+
+func isColumnar(path string) bool {
+	return filepath.Ext(path) == columnarExtension
+}
+
+func writeColumnar(path string, points dataPointList) (int64, error) {
+	columns := 0
+	for _, p := range points {
+		if len(p.record) > columns {
+			columns = len(p.record)
+		}
+	}
+
+	blocks := make([]bytes.Buffer, columns+1)
+	var previous uint64
+	for _, p := range points {
+		putUvarint(&blocks[0], p.timestamp-previous)
+		previous = p.timestamp
+
+		for c := 0; c < columns; c++ {
+			if c >= len(p.record) {
+				putUvarint(&blocks[c+1], 0)
+				continue
+			}
+			putUvarint(&blocks[c+1], uint64(len(p.record[c]))+1)
+			blocks[c+1].WriteString(p.record[c])
+		}
+	}
+
+	header := &bytes.Buffer{}
+	header.WriteString(columnarMagic)
+	putUvarint(header, uint64(len(points)))
+	putUvarint(header, uint64(columns))
+	for i := range blocks {
+		putUvarint(header, uint64(blocks[i].Len()))
+	}
+
+	// written to a temporary file, renamed once complete, like the CSV
+	// partitions
+	file, err := os.Create(path + tempExtension)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	counter := &countingWriter{writer: file}
+	_, err = header.WriteTo(counter)
+	for i := 0; err == nil && i < len(blocks); i++ {
+		_, err = blocks[i].WriteTo(counter)
+	}
+	if err == nil {
+		err = file.Close()
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return 0, err
+	}
+
+	meta := &partitionMeta{
+		File:  filepath.Base(path),
+		Size:  counter.count,
+		CRC32: counter.crc,
+		// the statistics are computed for all the rows
+		HasStats: true,
+	}
+	for _, p := range points {
+		meta.add(p.timestamp, p.record)
+	}
+	err = writePartitionMeta(path, meta)
+	if err != nil {
+		return 0, err
+	}
+
+	return counter.count, nil
+}
+
+// readColumnar reads the partition in columnar format, calling the handler
+// for each row
+func readColumnar(path string, opts readOptions, handler func(uint64, []string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+
+	reader := &byteCounter{reader: bufio.NewReader(file)}
+
+	magic := make([]byte, len(columnarMagic))
+	_, err = io.ReadFull(reader, magic)
+	if err != nil || string(magic) != columnarMagic {
+		return ErrInvalidColumnarFile
+	}
+
+	rows, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return ErrInvalidColumnarFile
+	}
+	columnsCount, err := binary.ReadUvarint(reader)
+	// the length of each block takes at least a byte
+	if err != nil || columnsCount >= uint64(size) {
+		return ErrInvalidColumnarFile
+	}
+
+	offsets := make([]int64, columnsCount+2)
+	offsets[0] = 0
+	for i := uint64(0); i <= columnsCount; i++ {
+		length, err := binary.ReadUvarint(reader)
+		if err != nil || length > uint64(size-offsets[i]) {
+			return ErrInvalidColumnarFile
+		}
+		offsets[i+1] = offsets[i] + int64(length)
+	}
+	for i := range offsets {
+		offsets[i] += reader.count
+	}
+	// the blocks end with the file, and each row takes at least a byte in
+	// every block
+	if offsets[len(offsets)-1] != size {
+		return ErrInvalidColumnarFile
+	}
+	for i := 1; i < len(offsets); i++ {
+		if rows > uint64(offsets[i]-offsets[i-1]) {
+			return ErrInvalidColumnarFile
+		}
+	}
+
+	columns := opts.columns
+	if columns == nil {
+		columns = make([]int, columnsCount)
+		for i := range columns {
+			columns[i] = i
+		}
+	}
+
+	readBlock := func(index int) (*bytes.Reader, error) {
+		block := make([]byte, offsets[index+1]-offsets[index])
+		_, err := file.ReadAt(block, offsets[index])
+		if err != nil {
+			return nil, ErrInvalidColumnarFile
+		}
+		return bytes.NewReader(block), nil
+	}
+
+	timestampsBlock, err := readBlock(0)
+	if err != nil {
+		return err
+	}
+	timestamps := make([]uint64, rows)
+	var previous uint64
+	for i := range timestamps {
+		delta, err := binary.ReadUvarint(timestampsBlock)
+		if err != nil {
+			return ErrInvalidColumnarFile
+		}
+		previous += delta
+		timestamps[i] = previous
+	}
+
+	values := make([][]*string, len(columns))
+	for i, column := range columns {
+		values[i] = make([]*string, rows)
+		if column >= int(columnsCount) {
+			continue
+		}
+		block, err := readBlock(column + 1)
+		if err != nil {
+			return err
+		}
+		for row := range values[i] {
+			length, err := binary.ReadUvarint(block)
+			if err != nil {
+				return ErrInvalidColumnarFile
+			}
+			if length == 0 {
+				continue
+			}
+			if length-1 > uint64(block.Len()) {
+				return ErrInvalidColumnarFile
+			}
+			value := make([]byte, length-1)
+			_, err = io.ReadFull(block, value)
+			if err != nil {
+				return ErrInvalidColumnarFile
+			}
+			s := string(value)
+			values[i][row] = &s
+		}
+	}
+
+	for row, timestamp := range timestamps {
+		var record []string
+		if opts.columns != nil {
+			record = make([]string, len(columns))
+			for i := range columns {
+				if values[i][row] != nil {
+					record[i] = *values[i][row]
+				}
+			}
+		} else {
+			record = make([]string, 0, len(columns))
+			for i := range columns {
+				if values[i][row] == nil {
+					break
+				}
+				record = append(record, *values[i][row])
+			}
+		}
+
+		err = handler(timestamp, record)
+		if err != nil {
+			setErrorPosition(err, path, func(int) int { return row + 1 })
+			err = opts.handleRowError(err)
+			if err != nil {
+				return errOrNilIfEOF(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func putUvarint(buffer *bytes.Buffer, value uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], value)
+	buffer.Write(scratch[:n])
+}
+
+// byteCounter counts the bytes read from the underlying reader
+type byteCounter struct {
+	reader *bufio.Reader
+	count  int64
+}
+
+func (b *byteCounter) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	b.count += int64(n)
+	return n, err
+}
+
+func (b *byteCounter) ReadByte() (byte, error) {
+	c, err := b.reader.ReadByte()
+	if err == nil {
+		b.count++
+	}
+	return c, err
+}
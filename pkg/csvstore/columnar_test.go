@@ -0,0 +1,136 @@
+package csvstore
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/pasdam/go-io-utilx/pkg/ioutilx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isColumnar(t *testing.T) {
+	assert.True(t, isColumnar(filepath.Join("some-dir", "0_9.col")))
+	assert.False(t, isColumnar(filepath.Join("some-dir", "0_9.csv")))
+	assert.False(t, isColumnar(filepath.Join("some-dir", "0_9.csv.gz")))
+}
+
+func Test_writeColumnar_readColumnar(t *testing.T) {
+	tests := []struct {
+		name   string
+		points dataPointList
+	}{
+		{
+			name:   "Should read empty partition",
+			points: dataPointList{},
+		},
+		{
+			name: "Should read partition with the same columns in each row",
+			points: dataPointList{
+				{timestamp: 10, record: []string{"some-value-at-10", "1.5"}},
+				{timestamp: 12, record: []string{"", "2"}},
+				{timestamp: 1000, record: []string{"some-value-at-1000", "-3"}},
+			},
+		},
+		{
+			name: "Should read partition with a different number of columns in each row",
+			points: dataPointList{
+				{timestamp: 0, record: []string{}},
+				{timestamp: 1, record: []string{"some-value-at-1", "some-other-value-at-1"}},
+				{timestamp: 2, record: []string{"some-value-at-2"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(filestest.TempDir(t), "0_9.col")
+
+			n, err := writeColumnar(path, tt.points)
+			assert.Nil(t, err)
+			assert.True(t, n > 0)
+			assert.NoFileExists(t, path+tempExtension)
+
+			got := dataPointList{}
+			err = readColumnar(path, readOptions{}, newRecordsCollector((*[]*dataPoint)(&got)))
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.points, got)
+		})
+	}
+}
+
+func Test_readColumnar(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		handlerErr error
+		wantErr    error
+	}{
+		{
+			name:    "Should return error if file is not in columnar format",
+			content: "0,some-value-at-0\n",
+			wantErr: ErrInvalidColumnarFile,
+		},
+		{
+			name:    "Should return error if file is truncated",
+			content: columnarMagic + "\x02\x01\x02\x04\x00",
+			wantErr: ErrInvalidColumnarFile,
+		},
+		{
+			name:    "Should return error if the row count is damaged",
+			content: columnarMagic + "\xff\xff\xff\xff\xff\xff\xff\xff\x7f\x00\x02\x01\x02",
+			wantErr: ErrInvalidColumnarFile,
+		},
+		{
+			name:    "Should return error if the column count is damaged",
+			content: columnarMagic + "\x02\xff\xff\xff\xff\xff\xff\xff\xff\x7f\x02\x01\x02",
+			wantErr: ErrInvalidColumnarFile,
+		},
+		{
+			name:    "Should return error if a block length is damaged",
+			content: columnarMagic + "\x02\x00\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01\x01\x02",
+			wantErr: ErrInvalidColumnarFile,
+		},
+		{
+			name:    "Should return error if a value length is damaged",
+			content: columnarMagic + "\x01\x01\x01\x02\x01\x7f",
+			wantErr: ErrInvalidColumnarFile,
+		},
+		{
+			name:       "Should return error if handler raises it",
+			handlerErr: errors.New("some-handler-error"),
+			wantErr:    errors.New("some-handler-error"),
+		},
+		{
+			name:       "Should stop without error if handler returns EOF",
+			handlerErr: io.EOF,
+			wantErr:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(filestest.TempDir(t), "0_9.col")
+			if len(tt.content) > 0 {
+				err := ioutilx.ReaderToFile(strings.NewReader(tt.content), path)
+				assert.Nil(t, err)
+			} else {
+				_, err := writeColumnar(path, dataPointList{{timestamp: 1}, {timestamp: 2}})
+				assert.Nil(t, err)
+			}
+			calls := 0
+
+			err := readColumnar(path, readOptions{}, func(uint64, []string) error {
+				calls++
+				return tt.handlerErr
+			})
+
+			assert.Equal(t, tt.wantErr, err)
+			if tt.handlerErr != nil {
+				assert.Equal(t, 1, calls)
+			}
+		})
+	}
+}
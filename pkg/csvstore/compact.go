@@ -0,0 +1,162 @@
+package csvstore
+
+import (
+	"os"
+	"strings"
+)
+
+// Compact converts the CSV partitions older than the cold age set with
+// WithColdAge, relative to the last point in the store, into the columnar
+// format, and returns the paths of the converted files. It does nothing if
+// the cold age is not set.
+func (s *Store) Compact() ([]string, error) {
+	if s.coldAge == 0 {
+		return nil, nil
+	}
+
+	last, _, err := s.LastPoint()
+	if err != nil {
+		return nil, err
+	}
+	if last < s.coldAge {
+		return nil, nil
+	}
+
+	return s.CompactBefore(last - s.coldAge)
+}
+
+// CompactBefore converts the CSV partitions whose interval ends before the
+// timestamp into the columnar format, and returns the paths of the converted
+// files. The columnar partitions are read transparently by the store, and are
+// converted back to CSV when new points are stored in them. The files of the
+// same partition with different compressions are merged into one, preferring
+// the points of the file read by the store.
+func (s *Store) CompactBefore(timestamp uint64) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	names, err := listDatasets(s.dir, s.index.signed)
+	if err != nil {
+		return nil, err
+	}
+
+	var froms []uint64
+	sources := make(map[uint64][]string)
+	for _, name := range names {
+		from, to, _ := parseDatasetName(name, s.index.signed)
+		if to >= timestamp {
+			continue
+		}
+		if _, ok := sources[from]; !ok {
+			froms = append(froms, from)
+		}
+		sources[from] = append(sources[from], name)
+	}
+
+	var converted []string
+	for _, from := range froms {
+		_, to := timestampToInterval(from, s.index.interval, s.index.signed)
+		columnarPath := s.path(datasetName(from, to, s.index.signed))
+		columnarPath = strings.TrimSuffix(columnarPath, ".csv") + columnarExtension
+
+		paths := s.compactionSources(columnarPath, sources[from])
+		if len(paths) == 1 && paths[0] == columnarPath {
+			continue
+		}
+
+		var points dataPointList
+		for _, path := range paths {
+			partition, err := s.readDataset(path)
+			if err != nil {
+				return converted, err
+			}
+			points, _, _ = mergePoints(points, partition, ReplaceOnConflict)
+		}
+
+		_, err = writeColumnar(columnarPath, points)
+		if err != nil {
+			return converted, err
+		}
+
+		for _, path := range paths {
+			if path == columnarPath {
+				continue
+			}
+			err = os.Remove(path)
+			if err != nil {
+				return converted, err
+			}
+		}
+
+		converted = append(converted, columnarPath)
+	}
+
+	return converted, nil
+}
+
+// compactionSources returns the paths of the files of a partition, in the
+// reverse order of the one used by the store to locate them, so that the
+// points of the file read by the store are merged last
+func (s *Store) compactionSources(columnarPath string, names []string) []string {
+	csvPath := strings.TrimSuffix(columnarPath, columnarExtension) + ".csv"
+	candidates := []string{columnarPath}
+	for i := len(compressions) - 1; i >= 0; i-- {
+		if compressions[i] != s.compression {
+			candidates = append(candidates, csvPath+compressions[i].suffix())
+		}
+	}
+	candidates = append(candidates, csvPath+s.compression.suffix())
+
+	present := make(map[string]bool, len(names))
+	for _, name := range names {
+		present[s.path(name)] = true
+	}
+
+	var paths []string
+	for _, candidate := range candidates {
+		if present[candidate] {
+			paths = append(paths, candidate)
+		}
+	}
+	return paths
+}
+
+// Expand converts the columnar partitions between from and to back to CSV,
+// with the compression of the store, and returns the paths of the converted
+// files
+func (s *Store) Expand(from uint64, to uint64) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var converted []string
+	for _, name := range s.index.findDatasets(from, to) {
+		columnarPath := s.path(strings.TrimSuffix(name, ".csv") + columnarExtension)
+		_, err := os.Stat(columnarPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return converted, err
+		}
+
+		points, err := s.readDataset(columnarPath)
+		if err != nil {
+			return converted, err
+		}
+
+		ds := &dataset{
+			path:     s.path(name) + s.compression.suffix(),
+			points:   points,
+			replaces: columnarPath,
+			format:   s.fileFormat(),
+		}
+		_, err = writeDataset(ds)
+		if err != nil {
+			return converted, err
+		}
+
+		converted = append(converted, ds.path)
+	}
+
+	return converted, nil
+}
@@ -0,0 +1,157 @@
+package csvstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/pasdam/go-io-utilx/pkg/ioutilx"
+	"github.com/pasdam/mockit/mockit"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCompactTestStore(t *testing.T, opts ...Option) *Store {
+	dir := filestest.TempDir(t)
+	files := map[string]string{
+		"0_9.csv":    "1,some-value-at-1\n3,some-value-at-3\n",
+		"10_19.csv":  "11,some-value-at-11\n",
+		"20_29.csv":  "21,some-value-at-21\n",
+		"30_39.csv":  "35,some-value-at-35\n",
+		"other.json": "{}",
+	}
+	for name, content := range files {
+		err := ioutilx.ReaderToFile(strings.NewReader(content), filepath.Join(dir, name))
+		assert.Nil(t, err)
+	}
+	return NewStore(dir, 10, opts...)
+}
+
+func loadAll(t *testing.T, s *Store, from uint64, to uint64) []Point {
+	var points []Point
+	err := s.LoadPoints(from, to, func(timestamp uint64, record []string) error {
+		points = append(points, Point{Timestamp: timestamp, Record: record})
+		return nil
+	})
+	assert.Nil(t, err)
+	return points
+}
+
+func TestStore_CompactBefore(t *testing.T) {
+	s := newCompactTestStore(t)
+	want := loadAll(t, s, 0, 39)
+
+	got, err := s.CompactBefore(20)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(s.dir, "0_9.col"), filepath.Join(s.dir, "10_19.col")}, got)
+	assert.NoFileExists(t, filepath.Join(s.dir, "0_9.csv"))
+	assert.NoFileExists(t, filepath.Join(s.dir, "10_19.csv"))
+	assert.FileExists(t, filepath.Join(s.dir, "20_29.csv"))
+	assert.Equal(t, want, loadAll(t, s, 0, 39))
+
+	got, err = s.CompactBefore(20)
+
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+}
+
+func TestStore_CompactBefore_ShouldMergeFilesOfTheSamePartition(t *testing.T) {
+	s := newCompactTestStore(t, WithCompression(Gzip))
+	_, err := writeDataset(&dataset{
+		path:   filepath.Join(s.dir, "0_9.csv.gz"),
+		points: dataPointList{{timestamp: 2, record: []string{"some-value-at-2"}}, {timestamp: 3, record: []string{"some-other-value-at-3"}}},
+		format: s.fileFormat(),
+	})
+	assert.Nil(t, err)
+
+	got, err := s.CompactBefore(10)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(s.dir, "0_9.col")}, got)
+	assert.NoFileExists(t, filepath.Join(s.dir, "0_9.csv"))
+	assert.NoFileExists(t, filepath.Join(s.dir, "0_9.csv.gz"))
+	assert.Equal(t, []Point{
+		{Timestamp: 1, Record: []string{"some-value-at-1"}},
+		{Timestamp: 2, Record: []string{"some-value-at-2"}},
+		{Timestamp: 3, Record: []string{"some-other-value-at-3"}},
+	}, loadAll(t, s, 0, 9))
+}
+
+func TestStore_CompactBefore_ShouldKeepCSVIfColumnarFileCantBeWritten(t *testing.T) {
+	s := newCompactTestStore(t)
+	wantErr := errors.New("some-rename-error")
+	mockit.MockFunc(t, os.Rename).With(filepath.Join(s.dir, "0_9.col"+tempExtension), filepath.Join(s.dir, "0_9.col")).Return(wantErr)
+
+	got, err := s.CompactBefore(10)
+
+	assert.Equal(t, wantErr, err)
+	assert.Empty(t, got)
+	assert.FileExists(t, filepath.Join(s.dir, "0_9.csv"))
+	assert.NoFileExists(t, filepath.Join(s.dir, "0_9.col"))
+	assert.NoFileExists(t, filepath.Join(s.dir, "0_9.col"+tempExtension))
+}
+
+func TestStore_Compact(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			name: "Should do nothing if cold age is not set",
+		},
+		{
+			name: "Should compact partitions older than the cold age",
+			opts: []Option{WithColdAge(15)},
+			want: []string{"0_9.col", "10_19.col"},
+		},
+		{
+			name: "Should do nothing if cold age is greater than the last point",
+			opts: []Option{WithColdAge(100)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newCompactTestStore(t, tt.opts...)
+
+			got, err := s.Compact()
+
+			assert.Nil(t, err)
+			assert.Equal(t, len(tt.want), len(got))
+			for i, name := range tt.want {
+				assert.Equal(t, filepath.Join(s.dir, name), got[i])
+			}
+		})
+	}
+}
+
+func TestStore_Expand(t *testing.T) {
+	s := newCompactTestStore(t, WithCompression(Gzip))
+	want := loadAll(t, s, 0, 39)
+	_, err := s.CompactBefore(30)
+	assert.Nil(t, err)
+
+	got, err := s.Expand(5, 15)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(s.dir, "0_9.csv.gz"), filepath.Join(s.dir, "10_19.csv.gz")}, got)
+	assert.NoFileExists(t, filepath.Join(s.dir, "0_9.col"))
+	assert.NoFileExists(t, filepath.Join(s.dir, "10_19.col"))
+	assert.FileExists(t, filepath.Join(s.dir, "20_29.col"))
+	assert.Equal(t, want, loadAll(t, s, 0, 39))
+}
+
+func TestStore_StorePoints_ShouldConvertColdPartitionBackToCSV(t *testing.T) {
+	s := newCompactTestStore(t)
+	_, err := s.CompactBefore(10)
+	assert.Nil(t, err)
+
+	err = s.Append(5, []string{"some-value-at-5"})
+
+	assert.Nil(t, err)
+	assert.NoFileExists(t, filepath.Join(s.dir, "0_9.col"))
+	filestest.FileExistsWithContent(t, filepath.Join(s.dir, "0_9.csv"), "1,some-value-at-1\n3,some-value-at-3\n5,some-value-at-5\n")
+}
@@ -0,0 +1,62 @@
+package csvstore
+
+import "fmt"
+
+// CompareOp is the operator of a comparison between a column and a value
+type CompareOp int
+
+const (
+	// Equal matches the values equal to the operand
+	Equal CompareOp = iota
+	// NotEqual matches the values different from the operand
+	NotEqual
+	// Less matches the values less than the operand
+	Less
+	// LessOrEqual matches the values less than or equal to the operand
+	LessOrEqual
+	// Greater matches the values greater than the operand
+	Greater
+	// GreaterOrEqual matches the values greater than or equal to the operand
+	GreaterOrEqual
+)
+
+// String returns the symbol of the operator, e.g. "<="
+func (o CompareOp) String() string {
+	switch o {
+	case Equal:
+		return "=="
+	case NotEqual:
+		return "!="
+	case Less:
+		return "<"
+	case LessOrEqual:
+		return "<="
+	case Greater:
+		return ">"
+	case GreaterOrEqual:
+		return ">="
+	default:
+		return fmt.Sprintf("CompareOp(%d)", int(o))
+	}
+}
+
+// holds returns true if the result of the comparison of a value with the
+// operand, as returned by strings.Compare, satisfies the operator
+func (o CompareOp) holds(comparison int) bool {
+	switch o {
+	case Equal:
+		return comparison == 0
+	case NotEqual:
+		return comparison != 0
+	case Less:
+		return comparison < 0
+	case LessOrEqual:
+		return comparison <= 0
+	case Greater:
+		return comparison > 0
+	case GreaterOrEqual:
+		return comparison >= 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,29 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareOp(t *testing.T) {
+	tests := []struct {
+		op         CompareOp
+		wantString string
+		wantHolds  [3]bool
+	}{
+		{op: Equal, wantString: "==", wantHolds: [3]bool{false, true, false}},
+		{op: NotEqual, wantString: "!=", wantHolds: [3]bool{true, false, true}},
+		{op: Less, wantString: "<", wantHolds: [3]bool{true, false, false}},
+		{op: LessOrEqual, wantString: "<=", wantHolds: [3]bool{true, true, false}},
+		{op: Greater, wantString: ">", wantHolds: [3]bool{false, false, true}},
+		{op: GreaterOrEqual, wantString: ">=", wantHolds: [3]bool{false, true, true}},
+		{op: CompareOp(10), wantString: "CompareOp(10)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantString, func(t *testing.T) {
+			assert.Equal(t, tt.wantString, tt.op.String())
+			assert.Equal(t, tt.wantHolds, [3]bool{tt.op.holds(-1), tt.op.holds(0), tt.op.holds(1)})
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package csvstore
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type comparePredicate struct {
+	column string
+	op     CompareOp
+	value  string
+}
+
+// Compare returns the predicate comparing the values of a column with the
+// specified value. The column is either the name of a column of the schema of
+// the store, or its index, and its values are compared according to the type
+// in the schema. Empty or missing values, the ones that can't be parsed as
+// the type of the column, and NaN ones in numeric comparisons, don't match.
+func Compare(column string, op CompareOp, value string) Predicate {
+	return &comparePredicate{column: column, op: op, value: value}
+}
+
+func (p *comparePredicate) bind(schema Schema) (rowFilter, error) {
+	index, columnType, err := schema.resolve(p.column)
+	if err != nil {
+		return nil, err
+	}
+	f := &compareFilter{index: index, op: p.op, columnType: columnType, value: p.value}
+
+	switch f.columnType {
+	case IntColumn:
+		f.integer, err = strconv.ParseInt(p.value, 10, 64)
+		f.number = float64(f.integer)
+	case FloatColumn:
+		f.number, err = strconv.ParseFloat(p.value, 64)
+	case AutoColumn:
+		number, parseErr := strconv.ParseFloat(p.value, 64)
+		f.number, f.numeric = number, parseErr == nil && !math.IsNaN(number)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %v %q: %v", ErrInvalidPredicate, p.column, p.op, p.value, err)
+	}
+
+	return f, nil
+}
+
+// compareFilter is a bound comparePredicate
+type compareFilter struct {
+	index      int
+	op         CompareOp
+	columnType ColumnType
+	value      string
+	integer    int64
+	number     float64
+	numeric    bool
+}
+
+func (f *compareFilter) match(record []string) bool {
+	if f.index >= len(record) || len(record[f.index]) == 0 {
+		return false
+	}
+	value := record[f.index]
+
+	switch f.columnType {
+	case StringColumn:
+		return f.op.holds(strings.Compare(value, f.value))
+
+	case IntColumn:
+		integer, err := strconv.ParseInt(value, 10, 64)
+		return err == nil && f.op.holds(compareNumbers(float64(integer), f.number, integer, f.integer))
+
+	case FloatColumn:
+		number, err := strconv.ParseFloat(value, 64)
+		return err == nil && f.matchNumber(number)
+
+	default:
+		if f.numeric {
+			number, err := strconv.ParseFloat(value, 64)
+			if err == nil {
+				return f.matchNumber(number)
+			}
+		}
+		return f.op.holds(strings.Compare(value, f.value))
+	}
+}
+
+// matchNumber returns true if the float value matches; NaN values, that are
+// not counted as numeric in the statistics, don't match
+func (f *compareFilter) matchNumber(number float64) bool {
+	if math.IsNaN(number) || math.IsNaN(f.number) {
+		return false
+	}
+	return f.op.holds(compareNumbers(number, f.number, 0, 0))
+}
+
+func (f *compareFilter) mayMatch(stats []columnStats) bool {
+	if f.index >= len(stats) {
+		// no row has a value for the column
+		return false
+	}
+	column := stats[f.index]
+
+	switch f.columnType {
+	case StringColumn:
+		return column.Numeric+column.Other > 0
+	case AutoColumn:
+		if !f.numeric || column.Other > 0 {
+			return column.Numeric+column.Other > 0
+		}
+	}
+	if column.Numeric == 0 {
+		return false
+	}
+
+	switch f.op {
+	case Equal:
+		return column.Min <= f.number && f.number <= column.Max
+	case NotEqual:
+		return column.Min != f.number || column.Max != f.number
+	case Less:
+		return column.Min < f.number
+	case LessOrEqual:
+		return column.Min <= f.number
+	case Greater:
+		return column.Max > f.number
+	case GreaterOrEqual:
+		return column.Max >= f.number
+	default:
+		return true
+	}
+}
+
+func (f *compareFilter) columns() []int {
+	return []int{f.index}
+}
+
+func (f *compareFilter) remap(positions map[int]int) rowFilter {
+	remapped := *f
+	remapped.index = positions[f.index]
+	return &remapped
+}
+
+// compareNumbers compares two numbers, using the integers if the floats are
+// equal, since they could have lost precision
+func compareNumbers(left float64, right float64, leftInteger int64, rightInteger int64) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	case leftInteger < rightInteger:
+		return -1
+	case leftInteger > rightInteger:
+		return 1
+	default:
+		return 0
+	}
+}
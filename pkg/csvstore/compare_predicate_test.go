@@ -0,0 +1,246 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var predicateTestSchema = Schema{
+	{Name: "symbol", Type: StringColumn},
+	{Name: "qty", Type: IntColumn},
+	{Name: "price", Type: FloatColumn},
+	{Name: "note"},
+}
+
+func TestCompare_match(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+		op     CompareOp
+		value  string
+		record []string
+		want   bool
+	}{
+		{
+			name:   "Should compare strings",
+			column: "symbol",
+			op:     Less,
+			value:  "Y",
+			record: []string{"X"},
+			want:   true,
+		},
+		{
+			name:   "Should compare integers",
+			column: "qty",
+			op:     Greater,
+			value:  "9",
+			record: []string{"X", "10"},
+			want:   true,
+		},
+		{
+			name:   "Should compare large integers without losing precision",
+			column: "qty",
+			op:     Greater,
+			value:  "9007199254740992",
+			record: []string{"X", "9007199254740993"},
+			want:   true,
+		},
+		{
+			name:   "Should not match values that are not integers",
+			column: "qty",
+			op:     NotEqual,
+			value:  "9",
+			record: []string{"X", "1.5"},
+		},
+		{
+			name:   "Should compare floats",
+			column: "price",
+			op:     GreaterOrEqual,
+			value:  "100",
+			record: []string{"X", "1", "100.0"},
+			want:   true,
+		},
+		{
+			name:   "Should compare numeric values of untyped columns as numbers",
+			column: "note",
+			op:     Less,
+			value:  "10",
+			record: []string{"X", "1", "1", "9"},
+			want:   true,
+		},
+		{
+			name:   "Should compare other values of untyped columns as strings",
+			column: "note",
+			op:     Less,
+			value:  "10",
+			record: []string{"X", "1", "1", "a"},
+		},
+		{
+			name:   "Should not match NaN floats",
+			column: "price",
+			op:     LessOrEqual,
+			value:  "5",
+			record: []string{"X", "1", "NaN"},
+		},
+		{
+			name:   "Should not match floats with NaN value",
+			column: "price",
+			op:     Equal,
+			value:  "NaN",
+			record: []string{"X", "1", "NaN"},
+		},
+		{
+			name:   "Should not match NaN numbers of untyped columns",
+			column: "note",
+			op:     Equal,
+			value:  "5",
+			record: []string{"X", "1", "1.5", "NaN"},
+		},
+		{
+			name:   "Should select columns by index",
+			column: "4",
+			op:     Equal,
+			value:  "a",
+			record: []string{"X", "1", "1", "", "a"},
+			want:   true,
+		},
+		{
+			name:   "Should not match missing values",
+			column: "4",
+			op:     NotEqual,
+			value:  "a",
+			record: []string{"X"},
+		},
+		{
+			name:   "Should not match empty values",
+			column: "symbol",
+			op:     NotEqual,
+			value:  "a",
+			record: []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := Compare(tt.column, tt.op, tt.value).bind(predicateTestSchema)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.want, filter.match(tt.record))
+		})
+	}
+}
+
+func TestCompare_bind_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		column  string
+		value   string
+		wantErr error
+	}{
+		{
+			name:    "Should return error if column is unknown",
+			column:  "missing",
+			wantErr: ErrUnknownColumn,
+		},
+		{
+			name:    "Should return error if index is negative",
+			column:  "-1",
+			wantErr: ErrUnknownColumn,
+		},
+		{
+			name:    "Should return error if value is not an integer",
+			column:  "qty",
+			value:   "1.5",
+			wantErr: ErrInvalidPredicate,
+		},
+		{
+			name:    "Should return error if value is not a float",
+			column:  "price",
+			value:   "abc",
+			wantErr: ErrInvalidPredicate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compare(tt.column, Equal, tt.value).bind(predicateTestSchema)
+
+			assert.True(t, errors.Is(err, tt.wantErr), err)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestCompare_mayMatch(t *testing.T) {
+	numeric := columnStats{Numeric: 2, Min: 10, Max: 20}
+	mixed := columnStats{Numeric: 2, Other: 1, Min: 10, Max: 20}
+	tests := []struct {
+		name   string
+		column string
+		op     CompareOp
+		value  string
+		stats  []columnStats
+		want   bool
+	}{
+		{
+			name:   "Should match value in the range",
+			column: "price",
+			op:     Equal,
+			value:  "15",
+			stats:  []columnStats{{}, {}, numeric},
+			want:   true,
+		},
+		{
+			name:   "Should not match value outside the range",
+			column: "price",
+			op:     Greater,
+			value:  "20",
+			stats:  []columnStats{{}, {}, numeric},
+		},
+		{
+			name:   "Should ignore other values of typed columns",
+			column: "qty",
+			op:     Less,
+			value:  "10",
+			stats:  []columnStats{{}, mixed},
+		},
+		{
+			name:   "Should match untyped columns with other values",
+			column: "note",
+			op:     Less,
+			value:  "10",
+			stats:  []columnStats{{}, {}, {}, mixed},
+			want:   true,
+		},
+		{
+			name:   "Should match string columns with values",
+			column: "symbol",
+			op:     Equal,
+			value:  "X",
+			stats:  []columnStats{{Other: 1}},
+			want:   true,
+		},
+		{
+			name:   "Should not match columns without values",
+			column: "symbol",
+			op:     NotEqual,
+			value:  "X",
+			stats:  []columnStats{},
+		},
+		{
+			name:   "Should not match not equal value if all values are equal",
+			column: "price",
+			op:     NotEqual,
+			value:  "10",
+			stats:  []columnStats{{}, {}, {Numeric: 3, Min: 10, Max: 10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := Compare(tt.column, tt.op, tt.value).bind(predicateTestSchema)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.want, filter.mayMatch(tt.stats))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package csvstore
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrInvalidCadence is returned by Completeness if the cadence is 0
+var ErrInvalidCadence = errors.New("invalid cadence")
+
+// Completeness analyses the points between from and to, expecting one every
+// cadence, and reports the missing intervals, the intervals with more than
+// one point per slot, and the completeness of each partition. The partitions
+// whose metadata show that the points are exactly cadence apart are not read.
+func (s *Store) Completeness(from uint64, to uint64, cadence uint64) (*CompletenessReport, error) {
+	if cadence == 0 {
+		return nil, ErrInvalidCadence
+	}
+
+	report := &CompletenessReport{}
+	if to < from {
+		return report, nil
+	}
+
+	tracker := &slotTracker{from: from, to: to, cadence: cadence}
+	partitionFrom, _ := timestampToInterval(from, s.index.interval, s.index.signed)
+	for {
+		_, partitionTo := timestampToInterval(partitionFrom, s.index.interval, s.index.signed)
+		p := &PartitionCompleteness{From: partitionFrom, To: partitionTo}
+		if p.From < from {
+			p.From = from
+		}
+		if p.To > to {
+			p.To = to
+		}
+
+		meta, err := s.cadenceMeta(datasetName(partitionFrom, partitionTo, s.index.signed), p.From, p.To, cadence)
+		if err != nil {
+			return nil, err
+		}
+		if meta != nil {
+			if meta.Rows > 0 {
+				tracker.addRun(meta.First, meta.Rows)
+			}
+			p.Rows = meta.Rows
+		} else {
+			err = s.LoadPoints(p.From, p.To, func(timestamp uint64, _ []string) error {
+				tracker.add(timestamp)
+				p.Rows++
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		report.Partitions = append(report.Partitions, p)
+
+		if partitionTo >= to {
+			break
+		}
+		partitionFrom = partitionTo + 1
+	}
+	tracker.finish()
+
+	for _, p := range report.Partitions {
+		first := (p.From - from + cadence - 1) / cadence
+		last := tracker.slot(p.To)
+		if first > last {
+			continue
+		}
+		p.Expected = int(last - first + 1)
+		p.Present = p.Expected - int(tracker.missing(first, last))
+		report.Expected += p.Expected
+		report.Present += p.Present
+	}
+	report.Gaps = tracker.gapIntervals()
+	report.Anomalies = tracker.anomalies
+
+	return report, nil
+}
+
+// cadenceMeta returns the metadata of the partition if its points between
+// from and to can be known without reading it, i.e. if it doesn't exist, or
+// its points are exactly cadence apart; nil otherwise
+func (s *Store) cadenceMeta(name string, from uint64, to uint64, cadence uint64) (*partitionMeta, error) {
+	if s.buffer != nil && len(s.buffer.snapshot(from, to, s.policy)) > 0 {
+		return nil, nil
+	}
+
+	path, err := s.locate(s.path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &partitionMeta{}, nil
+		}
+		return nil, err
+	}
+
+	meta, err := storedPartitionMeta(path)
+	if err != nil || meta == nil {
+		return nil, err
+	}
+
+	if meta.Rows > 0 && (meta.First < from || meta.Last > to) {
+		return nil, nil
+	}
+	if meta.Rows > 1 && (meta.MinStep != cadence || meta.MaxStep != cadence) {
+		return nil, nil
+	}
+
+	return meta, nil
+}
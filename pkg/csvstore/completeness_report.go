@@ -0,0 +1,35 @@
+package csvstore
+
+// CompletenessReport contains the result of the completeness analysis of the
+// points of a store, compared to an expected cadence. The range is divided in
+// slots of cadence size, starting from the beginning of the range, each one
+// expected to contain exactly one point.
+type CompletenessReport struct {
+	// Expected is the number of slots in the range
+	Expected int
+
+	// Present is the number of slots with at least one point
+	Present int
+
+	// Gaps contains the intervals without points, sorted
+	Gaps []Gap
+
+	// Anomalies contains the intervals with more than one point per slot,
+	// sorted
+	Anomalies []DensityAnomaly
+
+	// Partitions contains the completeness of each partition in the range,
+	// sorted by interval
+	Partitions []*PartitionCompleteness
+}
+
+// Percent returns the percentage of the expected slots with at least one
+// point, 100 if no slot is expected
+func (r *CompletenessReport) Percent() float64 {
+	return percent(r.Present, r.Expected)
+}
+
+// OK returns true if every slot contains exactly one point
+func (r *CompletenessReport) OK() bool {
+	return len(r.Gaps) == 0 && len(r.Anomalies) == 0
+}
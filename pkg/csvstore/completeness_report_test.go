@@ -0,0 +1,33 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletenessReport_Percent(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *CompletenessReport
+		want   float64
+	}{
+		{
+			name:   "Should return 100 if no slot is expected",
+			report: &CompletenessReport{},
+			want:   100,
+		},
+		{
+			name:   "Should return the percentage of the present slots",
+			report: &CompletenessReport{Expected: 8, Present: 6},
+			want:   75,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.report.Percent())
+			p := &PartitionCompleteness{Expected: tt.report.Expected, Present: tt.report.Present}
+			assert.Equal(t, tt.want, p.Percent())
+		})
+	}
+}
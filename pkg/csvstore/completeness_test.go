@@ -0,0 +1,102 @@
+package csvstore
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCompletenessTestStore(t *testing.T) *Store {
+	s := NewStore(filestest.TempDir(t), 10)
+	for _, ts := range []uint64{0, 2, 4, 6, 8, 10, 12, 16, 18, 20, 21, 22, 24, 26, 28} {
+		err := s.Append(ts, []string{"some-value"})
+		assert.Nil(t, err)
+	}
+	return s
+}
+
+func TestStore_Completeness(t *testing.T) {
+	tests := []struct {
+		name string
+		from uint64
+		to   uint64
+		want *CompletenessReport
+	}{
+		{
+			name: "Should report gaps, anomalies and partitions completeness",
+			from: 0,
+			to:   29,
+			want: &CompletenessReport{
+				Expected:  15,
+				Present:   14,
+				Gaps:      []Gap{{From: 14, To: 15}},
+				Anomalies: []DensityAnomaly{{From: 20, To: 21, Points: 2}},
+				Partitions: []*PartitionCompleteness{
+					{From: 0, To: 9, Rows: 5, Expected: 5, Present: 5},
+					{From: 10, To: 19, Rows: 4, Expected: 5, Present: 4},
+					{From: 20, To: 29, Rows: 6, Expected: 5, Present: 5},
+				},
+			},
+		},
+		{
+			name: "Should report missing partitions",
+			from: 4,
+			to:   39,
+			want: &CompletenessReport{
+				Expected:  18,
+				Present:   12,
+				Gaps:      []Gap{{From: 14, To: 15}, {From: 30, To: 39}},
+				Anomalies: []DensityAnomaly{{From: 20, To: 21, Points: 2}},
+				Partitions: []*PartitionCompleteness{
+					{From: 4, To: 9, Rows: 3, Expected: 3, Present: 3},
+					{From: 10, To: 19, Rows: 4, Expected: 5, Present: 4},
+					{From: 20, To: 29, Rows: 6, Expected: 5, Present: 5},
+					{From: 30, To: 39, Rows: 0, Expected: 5, Present: 0},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newCompletenessTestStore(t)
+
+			got, err := s.Completeness(tt.from, tt.to, 2)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.False(t, got.OK())
+		})
+	}
+}
+
+func TestStore_Completeness_ShouldUsePartitionMetadata(t *testing.T) {
+	s := newCompletenessTestStore(t)
+	content, err := ioutil.ReadFile(s.path("0_9.csv"))
+	assert.Nil(t, err)
+	// the partition can't be parsed, but its size is consistent with the metadata
+	err = ioutil.WriteFile(s.path("0_9.csv"), []byte(strings.Repeat("\"", len(content))), 0644)
+	assert.Nil(t, err)
+
+	got, err := s.Completeness(0, 9, 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &CompletenessReport{
+		Expected:   5,
+		Present:    5,
+		Partitions: []*PartitionCompleteness{{From: 0, To: 9, Rows: 5, Expected: 5, Present: 5}},
+	}, got)
+	assert.True(t, got.OK())
+	assert.Equal(t, float64(100), got.Percent())
+}
+
+func TestStore_Completeness_ShouldReturnErrorIfCadenceIsZero(t *testing.T) {
+	s := newCompletenessTestStore(t)
+
+	got, err := s.Completeness(0, 9, 0)
+
+	assert.Equal(t, ErrInvalidCadence, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,93 @@
+package csvstore
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the codec used to compress the partition files
+type Compression int
+
+const (
+	// NoCompression stores the partitions as plain CSV files (<from>_<to>.csv)
+	NoCompression Compression = iota
+
+	// Gzip stores the partitions as gzip compressed CSV files
+	// (<from>_<to>.csv.gz)
+	Gzip
+
+	// Zstd stores the partitions as zstd compressed CSV files
+	// (<from>_<to>.csv.zst)
+	Zstd
+)
+
+var compressions = []Compression{NoCompression, Gzip, Zstd}
+
+// compressionOf returns the compression of the file, based on its extension
+func compressionOf(path string) Compression {
+	for _, c := range compressions[1:] {
+		if strings.HasSuffix(path, c.suffix()) {
+			return c
+		}
+	}
+	return NoCompression
+}
+
+// suffix returns the suffix added to the name of the CSV files
+func (c Compression) suffix() string {
+	switch c {
+	case Gzip:
+		return ".gz"
+	case Zstd:
+		return ".zst"
+	default:
+		return ""
+	}
+}
+
+func (c Compression) newReader(r io.Reader) (io.ReadCloser, error) {
+	switch c {
+	case Gzip:
+		reader, err := gzip.NewReader(r)
+		if err == io.EOF {
+			// empty file
+			return ioutil.NopCloser(strings.NewReader("")), nil
+		}
+		return reader, err
+
+	case Zstd:
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+
+	default:
+		return ioutil.NopCloser(r), nil
+	}
+}
+
+func (c Compression) newWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case Gzip:
+		return gzip.NewWriter(w), nil
+
+	case Zstd:
+		return zstd.NewWriter(w)
+
+	default:
+		return nopWriteCloser{w}, nil
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,79 @@
+package csvstore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compressionOf(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want Compression
+	}{
+		{
+			name: "Should return NoCompression for plain CSV",
+			path: "0_9.csv",
+			want: NoCompression,
+		},
+		{
+			name: "Should return Gzip for gz files",
+			path: "0_9.csv.gz",
+			want: Gzip,
+		},
+		{
+			name: "Should return Zstd for zst files",
+			path: "0_9.csv.zst",
+			want: Zstd,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compressionOf(tt.path)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompression_readWrite(t *testing.T) {
+	for _, c := range compressions {
+		t.Run(c.suffix(), func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+
+			// write two streams, as when appending to a file
+			for _, content := range []string{"some-content\n", "some-other-content\n"} {
+				writer, err := c.newWriter(buffer)
+				assert.Nil(t, err)
+				_, err = writer.Write([]byte(content))
+				assert.Nil(t, err)
+				assert.Nil(t, writer.Close())
+			}
+
+			reader, err := c.newReader(buffer)
+			assert.Nil(t, err)
+			got, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Nil(t, reader.Close())
+
+			assert.Equal(t, "some-content\nsome-other-content\n", string(got))
+		})
+	}
+}
+
+func TestCompression_newReader_ShouldReadEmptyFile(t *testing.T) {
+	for _, c := range compressions {
+		t.Run(c.suffix(), func(t *testing.T) {
+			reader, err := c.newReader(&bytes.Buffer{})
+			assert.Nil(t, err)
+
+			got, err := ioutil.ReadAll(reader)
+
+			assert.Nil(t, err)
+			assert.Empty(t, got)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package csvstore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConflict is returned by ErrorOnConflict when a data point has the same
+// timestamp of an existing one
+var ErrConflict = errors.New("data point already exists")
+
+// ConflictPolicy resolves the conflict between a data point being stored and
+// an existing one with the same timestamp. It receives the timestamp, the
+// existing and the incoming records, and returns the record to store, or nil
+// to keep the existing one. A custom function can be used to merge the two
+// records.
+type ConflictPolicy func(timestamp uint64, existing []string, incoming []string) ([]string, error)
+
+// ReplaceOnConflict is the ConflictPolicy that replaces the existing data
+// point with the incoming one; this is the default policy of the store
+func ReplaceOnConflict(timestamp uint64, existing []string, incoming []string) ([]string, error) {
+	if incoming == nil {
+		return []string{}, nil
+	}
+	return incoming, nil
+}
+
+// KeepOnConflict is the ConflictPolicy that keeps the existing data point and
+// discards the incoming one
+func KeepOnConflict(timestamp uint64, existing []string, incoming []string) ([]string, error) {
+	return nil, nil
+}
+
+// ErrorOnConflict is the ConflictPolicy that aborts the operation returning
+// ErrConflict
+func ErrorOnConflict(timestamp uint64, existing []string, incoming []string) ([]string, error) {
+	return nil, fmt.Errorf("%w: %d", ErrConflict, timestamp)
+}
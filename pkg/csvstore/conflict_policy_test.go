@@ -0,0 +1,50 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceOnConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming []string
+		want     []string
+	}{
+		{
+			name:     "Should return the incoming record",
+			incoming: []string{"some-incoming-value"},
+			want:     []string{"some-incoming-value"},
+		},
+		{
+			name:     "Should return an empty record if the incoming one is nil",
+			incoming: nil,
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReplaceOnConflict(1, []string{"some-existing-value"}, tt.incoming)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKeepOnConflict(t *testing.T) {
+	got, err := KeepOnConflict(1, []string{"some-existing-value"}, []string{"some-incoming-value"})
+
+	assert.Nil(t, err)
+	assert.Nil(t, got)
+}
+
+func TestErrorOnConflict(t *testing.T) {
+	got, err := ErrorOnConflict(12, []string{"some-existing-value"}, []string{"some-incoming-value"})
+
+	assert.Nil(t, got)
+	assert.True(t, errors.Is(err, ErrConflict))
+	assert.Equal(t, "data point already exists: 12", err.Error())
+}
@@ -0,0 +1,20 @@
+package csvstore
+
+import (
+	"io"
+)
+
+// countingWriter counts the bytes written to the underlying writer, and
+// computes their checksum
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+	crc    uint32
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count += int64(n)
+	w.crc = checksum(w.crc, p[:n])
+	return n, err
+}
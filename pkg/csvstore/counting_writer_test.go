@@ -0,0 +1,24 @@
+package csvstore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_countingWriter_Write(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	w := &countingWriter{writer: buffer}
+
+	n, err := w.Write([]byte("some-content"))
+	assert.Nil(t, err)
+	assert.Equal(t, 12, n)
+
+	n, err = w.Write([]byte("other"))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, n)
+
+	assert.Equal(t, int64(17), w.count)
+	assert.Equal(t, "some-contentother", buffer.String())
+}
@@ -0,0 +1,32 @@
+package csvstore
+
+// cursorHeap is a min-heap of store cursors, ordered by the timestamp of their
+// current point, and by the index of the store for the same timestamp
+type cursorHeap []*storeCursor
+
+func (h cursorHeap) Len() int {
+	return len(h)
+}
+
+func (h cursorHeap) Less(i, j int) bool {
+	left, right := h[i].point().timestamp, h[j].point().timestamp
+	if left != right {
+		return left < right
+	}
+	return h[i].index < h[j].index
+}
+
+func (h cursorHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *cursorHeap) Push(x interface{}) {
+	*h = append(*h, x.(*storeCursor))
+}
+
+func (h *cursorHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
@@ -1,5 +1,9 @@
 package csvstore
 
+import (
+	"sort"
+)
+
 type dataPointList []*dataPoint
 
 func (d dataPointList) Length() int {
@@ -9,3 +13,33 @@ func (d dataPointList) Length() int {
 func (d dataPointList) ElementAt(index int) interface{} {
 	return d[index]
 }
+
+func (d dataPointList) Len() int {
+	return len(d)
+}
+
+func (d dataPointList) Less(i, j int) bool {
+	return d[i].timestamp < d[j].timestamp
+}
+
+func (d dataPointList) Swap(i, j int) {
+	d[i], d[j] = d[j], d[i]
+}
+
+func (d dataPointList) CsvAtIndex(index int) []string {
+	return d[index].record
+}
+
+func (d dataPointList) TimestampAtIndex(index int) uint64 {
+	return d[index].timestamp
+}
+
+// between returns the sub list of points with timestamp between from and to
+func (d dataPointList) between(from uint64, to uint64) dataPointList {
+	start := sort.Search(len(d), func(i int) bool { return d[i].timestamp >= from })
+	end := sort.Search(len(d), func(i int) bool { return d[i].timestamp > to })
+	if start >= end {
+		return nil
+	}
+	return d[start:end]
+}
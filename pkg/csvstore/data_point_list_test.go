@@ -2,7 +2,11 @@ package csvstore
 
 import (
 	"reflect"
+	"sort"
+	"strconv"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_dataPointList_Length(t *testing.T) {
@@ -70,3 +74,60 @@ func Test_dataPointList_ElementAt(t *testing.T) {
 		})
 	}
 }
+
+func Test_dataPointList_TimeSeries(t *testing.T) {
+	d := dataPointList{
+		{timestamp: 2, record: []string{"some-value-at-2"}},
+		{timestamp: 0, record: []string{"some-value-at-0"}},
+		{timestamp: 1, record: []string{"some-value-at-1"}},
+	}
+
+	sort.Sort(d)
+
+	assert.Equal(t, 3, d.Len())
+	for i := 0; i < d.Len(); i++ {
+		assert.Equal(t, uint64(i), d.TimestampAtIndex(i))
+		assert.Equal(t, []string{"some-value-at-" + strconv.Itoa(i)}, d.CsvAtIndex(i))
+	}
+}
+
+func Test_dataPointList_between(t *testing.T) {
+	d := dataPointList{
+		{timestamp: 1},
+		{timestamp: 3},
+		{timestamp: 5},
+		{timestamp: 7},
+	}
+	type args struct {
+		from uint64
+		to   uint64
+	}
+	tests := []struct {
+		name string
+		args args
+		want dataPointList
+	}{
+		{
+			name: "Should return points in the range",
+			args: args{from: 2, to: 5},
+			want: dataPointList{{timestamp: 3}, {timestamp: 5}},
+		},
+		{
+			name: "Should return all points",
+			args: args{from: 0, to: 10},
+			want: d,
+		},
+		{
+			name: "Should return nil if no point is in the range",
+			args: args{from: 8, to: 10},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := d.between(tt.args.from, tt.args.to)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package csvstore
 
 type dataset struct {
-	path   string
-	points dataPointList
+	path     string
+	points   dataPointList
+	stats    InsertStats
+	append   bool
+	replaces string
+	format   fileFormat
 }
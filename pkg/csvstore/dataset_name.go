@@ -4,6 +4,9 @@ import (
 	"fmt"
 )
 
-func datasetName(from uint64, to uint64) string {
+func datasetName(from uint64, to uint64, signed bool) string {
+	if signed {
+		return fmt.Sprintf("%d_%d.csv", ToSigned(from), ToSigned(to))
+	}
 	return fmt.Sprintf("%d_%d.csv", from, to)
 }
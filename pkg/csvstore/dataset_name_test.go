@@ -6,8 +6,9 @@ import (
 
 func Test_datasetName(t *testing.T) {
 	type args struct {
-		from uint64
-		to   uint64
+		from   uint64
+		to     uint64
+		signed bool
 	}
 	tests := []struct {
 		args args
@@ -34,10 +35,18 @@ func Test_datasetName(t *testing.T) {
 			},
 			want: "234_789.csv",
 		},
+		{
+			args: args{
+				from:   FromSigned(-10),
+				to:     FromSigned(-1),
+				signed: true,
+			},
+			want: "-10_-1.csv",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
-			if got := datasetName(tt.args.from, tt.args.to); got != tt.want {
+			if got := datasetName(tt.args.from, tt.args.to, tt.args.signed); got != tt.want {
 				t.Errorf("datasetName() = %v, want %v", got, tt.want)
 			}
 		})
@@ -0,0 +1,331 @@
+package csvstore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidSeriesName is returned when the name of a series is not valid
+var ErrInvalidSeriesName = errors.New("invalid series name")
+
+// ErrSeriesNotFound is returned when a series doesn't exist
+var ErrSeriesNotFound = errors.New("series not found")
+
+// ErrSeriesExists is returned when creating a series that already exists
+var ErrSeriesExists = errors.New("series already exists")
+
+// DB is a database of named series, each one a Store in a sub-folder of the
+// root folder, sharing the same interval and options
+type DB struct {
+	root     string
+	interval uint64
+	opts     []Option
+	series   map[string]*Store
+	labels   *labelIndex
+	mutex    sync.Mutex
+}
+
+// NewDB creates a new instance of a DB in the root folder, whose series are
+// split into partitions of interval size, and configured with the options
+func NewDB(root string, interval uint64, opts ...Option) *DB {
+	return &DB{
+		root:     root,
+		interval: interval,
+		opts:     opts,
+		series:   make(map[string]*Store),
+	}
+}
+
+// Series returns the store of the series with the specified name, creating it
+// if it doesn't exist. The name is a slash separated path, e.g. "temp/room1";
+// a series can't be inside another one.
+func (db *DB) Series(name string) (*Store, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	return db.open(name)
+}
+
+// Create creates a new series, and returns its store
+func (db *DB) Create(name string) (*Store, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	exists, err := db.exists(name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: %s", ErrSeriesExists, name)
+	}
+
+	return db.open(name)
+}
+
+// List returns the names of the series in the database, sorted
+func (db *DB) List() ([]string, error) {
+	var names []string
+	err := filepath.Walk(db.root, func(current string, info os.FileInfo, err error) error {
+		if err != nil {
+			if current == db.root && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !info.IsDir() || current == db.root {
+			return nil
+		}
+
+		if !isSeriesDir(current) {
+			return nil
+		}
+
+		name, err := filepath.Rel(db.root, current)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(name))
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Delete closes the series, and deletes its folder
+func (db *DB) Delete(name string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	exists, err := db.exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSeriesNotFound, name)
+	}
+
+	err = db.close(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(db.path(name))
+	if err != nil {
+		return err
+	}
+
+	if db.labels != nil {
+		db.labels.remove(name)
+	}
+	return nil
+}
+
+// Rename closes the series, and moves it to the new name
+func (db *DB) Rename(name string, newName string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	exists, err := db.exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSeriesNotFound, name)
+	}
+
+	err = db.checkName(newName)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stat(db.path(newName))
+	if err == nil {
+		return fmt.Errorf("%w: %s", ErrSeriesExists, newName)
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	err = db.close(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(db.path(newName)), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(db.path(name), db.path(newName))
+	if err != nil {
+		return err
+	}
+
+	if db.labels != nil {
+		db.labels.add(newName, db.labels.labels[name])
+		db.labels.remove(name)
+	}
+	return nil
+}
+
+// Expire deletes the partitions older than the retention of each series, set
+// with WithRetention, and returns the paths of the deleted files
+func (db *DB) Expire() ([]string, error) {
+	names, err := db.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, name := range names {
+		s, err := db.Series(name)
+		if err != nil {
+			return deleted, err
+		}
+
+		paths, err := s.Expire()
+		deleted = append(deleted, paths...)
+		if err != nil {
+			return deleted, err
+		}
+	}
+
+	return deleted, nil
+}
+
+// Close closes all the open series
+func (db *DB) Close() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	var result error
+	for name := range db.series {
+		err := db.close(name)
+		if err != nil && result == nil {
+			result = err
+		}
+	}
+
+	return result
+}
+
+func (db *DB) open(name string) (*Store, error) {
+	s, ok := db.series[name]
+	if ok {
+		return s, nil
+	}
+
+	err := db.checkName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err = OpenStore(db.path(name), db.interval, db.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	db.series[name] = s
+	if db.labels != nil {
+		if _, ok := db.labels.labels[name]; !ok {
+			db.labels.add(name, nil)
+		}
+	}
+	return s, nil
+}
+
+func (db *DB) close(name string) error {
+	s, ok := db.series[name]
+	if !ok {
+		return nil
+	}
+
+	delete(db.series, name)
+	return s.Close()
+}
+
+// exists returns true if the series exists
+func (db *DB) exists(name string) (bool, error) {
+	err := db.checkName(name)
+	if err != nil {
+		return false, err
+	}
+
+	return isSeriesDir(db.path(name)), nil
+}
+
+// checkName returns an error if the name is not valid, or if it is inside
+// another series, or contains other series
+func (db *DB) checkName(name string) error {
+	if len(name) == 0 || path.Clean(name) != name || path.IsAbs(name) || name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return fmt.Errorf("%w: %q", ErrInvalidSeriesName, name)
+	}
+
+	for parent := path.Dir(name); parent != "."; parent = path.Dir(parent) {
+		if isSeriesDir(db.path(parent)) {
+			return fmt.Errorf("%w: %q is inside series %q", ErrInvalidSeriesName, name, parent)
+		}
+	}
+
+	if isSeriesDir(db.path(name)) {
+		return nil
+	}
+	child, err := seriesBelow(db.path(name))
+	if err != nil {
+		return err
+	}
+	if len(child) > 0 {
+		return fmt.Errorf("%w: %q contains series %q", ErrInvalidSeriesName, name, path.Join(name, child))
+	}
+
+	return nil
+}
+
+func (db *DB) path(name string) string {
+	return filepath.Join(db.root, filepath.FromSlash(name))
+}
+
+// isSeriesDir returns true if the folder contains a store
+func isSeriesDir(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, configFile))
+	return err == nil && !info.IsDir()
+}
+
+// errSeriesFound stops the walk of seriesBelow
+var errSeriesFound = errors.New("series found")
+
+// seriesBelow returns the relative, slash separated, path of the first series
+// in the folder, empty if none
+func seriesBelow(dir string) (string, error) {
+	var child string
+	err := filepath.Walk(dir, func(current string, info os.FileInfo, err error) error {
+		if err != nil {
+			if current == dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !info.IsDir() || current == dir || !isSeriesDir(current) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, current)
+		if err != nil {
+			return err
+		}
+		child = filepath.ToSlash(rel)
+		return errSeriesFound
+	})
+	if err != nil && err != errSeriesFound {
+		return "", err
+	}
+
+	return child, nil
+}
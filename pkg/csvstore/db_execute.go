@@ -0,0 +1,34 @@
+package csvstore
+
+import "fmt"
+
+// Execute parses the statement and runs it on its series, that must exist,
+// calling the handler with the timestamp and the values of each row
+func (db *DB) Execute(query string, handler func(uint64, []string) error) error {
+	stmt, err := ParseStatement(query)
+	if err != nil {
+		return err
+	}
+
+	s, err := db.existing(stmt.Series)
+	if err != nil {
+		return err
+	}
+	return s.Execute(stmt, handler)
+}
+
+// existing returns the store of the series, failing if it doesn't exist
+func (db *DB) existing(name string) (*Store, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	exists, err := db.exists(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSeriesNotFound, name)
+	}
+
+	return db.open(name)
+}
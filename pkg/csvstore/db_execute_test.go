@@ -0,0 +1,61 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDB_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		want       []Point
+		wantErr    error
+		wantSyntax bool
+	}{
+		{
+			name:  "Should run the statement on the series",
+			query: "SELECT max(0) FROM cpu/a WHERE time <= 5",
+			want:  []Point{{Timestamp: 0, Record: []string{"30"}}},
+		},
+		{
+			name:    "Should return error if the series doesn't exist",
+			query:   "SELECT * FROM cpu/b",
+			wantErr: ErrSeriesNotFound,
+		},
+		{
+			name:       "Should return error if the statement is not valid",
+			query:      "SELECT * FROM",
+			wantSyntax: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := NewDB(filestest.TempDir(t), 10)
+			s, err := db.Series("cpu/a")
+			assert.Nil(t, err)
+			_, err = s.StorePoints(Points{
+				{Timestamp: 1, Record: []string{"10"}},
+				{Timestamp: 3, Record: []string{"30"}},
+				{Timestamp: 7, Record: []string{"70"}},
+			})
+			assert.Nil(t, err)
+
+			var got []Point
+			err = db.Execute(tt.query, func(timestamp uint64, record []string) error {
+				got = append(got, Point{Timestamp: timestamp, Record: append([]string(nil), record...)})
+				return nil
+			})
+
+			var syntaxErr *SyntaxError
+			assert.Equal(t, tt.wantSyntax, errors.As(err, &syntaxErr))
+			if !tt.wantSyntax {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package csvstore
+
+import "fmt"
+
+// SetLabels replaces the labels of the series, persisting them in its folder
+func (db *DB) SetLabels(name string, labels Labels) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	index, err := db.labelIndex()
+	if err != nil {
+		return err
+	}
+
+	exists, err := db.exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSeriesNotFound, name)
+	}
+
+	config, err := readStoreConfig(db.path(name))
+	if err != nil {
+		return err
+	}
+	// the labels are copied, so that the caller can't change the index
+	labels = labels.clone()
+	config.Labels = labels
+	err = writeStoreConfig(db.path(name), config)
+	if err != nil {
+		return err
+	}
+
+	index.add(name, labels)
+	return nil
+}
+
+// Labels returns a copy of the labels of the series
+func (db *DB) Labels(name string) (Labels, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	index, err := db.labelIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	labels, ok := index.labels[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSeriesNotFound, name)
+	}
+
+	return labels.clone(), nil
+}
+
+// Select returns the sorted names of the series matching all the matchers, or
+// all the series if there are none
+func (db *DB) Select(matchers ...*Matcher) ([]string, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	index, err := db.labelIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return index.selectSeries(matchers), nil
+}
+
+// labelIndex returns the index of the labels, loading it from the series
+// folders the first time
+func (db *DB) labelIndex() (*labelIndex, error) {
+	if db.labels != nil {
+		return db.labels, nil
+	}
+
+	names, err := db.List()
+	if err != nil {
+		return nil, err
+	}
+
+	index := newLabelIndex()
+	for _, name := range names {
+		config, err := readStoreConfig(db.path(name))
+		if err != nil {
+			return nil, err
+		}
+		index.add(name, config.Labels)
+	}
+
+	db.labels = index
+	return index, nil
+}
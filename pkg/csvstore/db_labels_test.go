@@ -0,0 +1,104 @@
+package csvstore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDB_SetLabels(t *testing.T) {
+	root := filestest.TempDir(t)
+	db := NewDB(root, 10)
+	for name, labels := range map[string]Labels{
+		"cpu/a": {"host": "a", "region": "eu"},
+		"cpu/b": {"host": "b", "region": "eu"},
+		"cpu/c": {"host": "c", "region": "us"},
+	} {
+		_, err := db.Series(name)
+		assert.Nil(t, err)
+		err = db.SetLabels(name, labels)
+		assert.Nil(t, err)
+	}
+	filestest.FileExistsWithContent(t, filepath.Join(root, "cpu", "a", configFile), `{"unit":"s","signed":false,"labels":{"host":"a","region":"eu"}}`)
+	region, err := NewMatcher(MatchEqual, "region", "eu")
+	assert.Nil(t, err)
+	host, err := NewMatcher(MatchNotEqual, "host", "a")
+	assert.Nil(t, err)
+
+	got, err := db.Select(region, host)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cpu/b"}, got)
+
+	err = db.Rename("cpu/b", "cpu/d")
+	assert.Nil(t, err)
+	err = db.Delete("cpu/a")
+	assert.Nil(t, err)
+	_, err = db.Series("cpu/e")
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+
+	db = NewDB(root, 10)
+	defer db.Close()
+
+	got, err = db.Select(region)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cpu/d"}, got)
+	labels, err := db.Labels("cpu/d")
+	assert.Nil(t, err)
+	assert.Equal(t, Labels{"host": "b", "region": "eu"}, labels)
+	got, err = db.Select()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cpu/c", "cpu/d", "cpu/e"}, got)
+}
+
+func TestDB_SetLabels_ShouldReturnErrorIfSeriesDoesNotExist(t *testing.T) {
+	db := NewDB(filestest.TempDir(t), 10)
+
+	err := db.SetLabels("missing", Labels{"host": "a"})
+
+	assert.True(t, errors.Is(err, ErrSeriesNotFound))
+	_, err = db.Labels("missing")
+	assert.True(t, errors.Is(err, ErrSeriesNotFound))
+}
+
+func TestDB_Select_ShouldIndexSeriesCreatedAfterLoading(t *testing.T) {
+	db := NewDB(filestest.TempDir(t), 10)
+	defer db.Close()
+	got, err := db.Select()
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+
+	_, err = db.Series("some-series")
+	assert.Nil(t, err)
+
+	got, err = db.Select()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"some-series"}, got)
+}
+
+func TestDB_Labels_ShouldNotShareTheMaps(t *testing.T) {
+	db := NewDB(filestest.TempDir(t), 10)
+	defer db.Close()
+	_, err := db.Series("some-series")
+	assert.Nil(t, err)
+	labels := Labels{"host": "a"}
+	err = db.SetLabels("some-series", labels)
+	assert.Nil(t, err)
+
+	labels["host"] = "b"
+	got, err := db.Labels("some-series")
+	assert.Nil(t, err)
+	got["host"] = "c"
+
+	got, err = db.Labels("some-series")
+	assert.Nil(t, err)
+	assert.Equal(t, Labels{"host": "a"}, got)
+	host, err := NewMatcher(MatchEqual, "host", "a")
+	assert.Nil(t, err)
+	selected, err := db.Select(host)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"some-series"}, selected)
+}
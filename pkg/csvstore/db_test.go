@@ -0,0 +1,178 @@
+package csvstore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDB_Series(t *testing.T) {
+	tests := []struct {
+		name    string
+		series  string
+		wantErr error
+	}{
+		{
+			name:   "Should create a series",
+			series: "some-series",
+		},
+		{
+			name:   "Should create a nested series",
+			series: "temp/room1",
+		},
+		{
+			name:    "Should return error if name is empty",
+			wantErr: ErrInvalidSeriesName,
+		},
+		{
+			name:    "Should return error if name is not clean",
+			series:  "temp//room1",
+			wantErr: ErrInvalidSeriesName,
+		},
+		{
+			name:    "Should return error if name is absolute",
+			series:  "/temp",
+			wantErr: ErrInvalidSeriesName,
+		},
+		{
+			name:    "Should return error if name is outside the root",
+			series:  "../temp",
+			wantErr: ErrInvalidSeriesName,
+		},
+		{
+			name:    "Should return error if name is inside another series",
+			series:  "existing/room1",
+			wantErr: ErrInvalidSeriesName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := NewDB(filestest.TempDir(t), 10, WithRetention(100))
+			defer db.Close()
+			_, err := db.Series("existing")
+			assert.Nil(t, err)
+
+			got, err := db.Series(tt.series)
+
+			assert.True(t, errors.Is(err, tt.wantErr), err)
+			if tt.wantErr != nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, filepath.Join(db.root, filepath.FromSlash(tt.series)), got.dir)
+			assert.Equal(t, uint64(10), got.index.interval)
+			assert.Equal(t, uint64(100), got.retention)
+			assert.FileExists(t, filepath.Join(got.dir, configFile))
+
+			again, err := db.Series(tt.series)
+			assert.Nil(t, err)
+			assert.Same(t, got, again)
+		})
+	}
+}
+
+func TestDB_Create(t *testing.T) {
+	db := NewDB(filestest.TempDir(t), 10)
+	defer db.Close()
+
+	_, err := db.Create("temp/room1")
+	assert.Nil(t, err)
+
+	_, err = db.Create("temp/room1")
+	assert.True(t, errors.Is(err, ErrSeriesExists))
+
+	_, err = db.Create("temp")
+	assert.True(t, errors.Is(err, ErrInvalidSeriesName))
+	names, err := db.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"temp/room1"}, names)
+}
+
+func TestDB_List(t *testing.T) {
+	db := NewDB(filepath.Join(filestest.TempDir(t), "db"), 10)
+	defer db.Close()
+
+	got, err := db.List()
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+
+	for _, name := range []string{"temp/room2", "humidity", "temp/room1"} {
+		s, err := db.Series(name)
+		assert.Nil(t, err)
+		err = s.Append(1, []string{"some-value"})
+		assert.Nil(t, err)
+	}
+
+	got, err = db.List()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"humidity", "temp/room1", "temp/room2"}, got)
+}
+
+func TestDB_Delete(t *testing.T) {
+	db := NewDB(filestest.TempDir(t), 10)
+	defer db.Close()
+	s, err := db.Series("temp/room1")
+	assert.Nil(t, err)
+	err = s.Append(1, []string{"some-value"})
+	assert.Nil(t, err)
+
+	err = db.Delete("temp/room1")
+
+	assert.Nil(t, err)
+	assert.NoDirExists(t, s.dir)
+	got, err := db.List()
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+
+	err = db.Delete("temp/room1")
+	assert.True(t, errors.Is(err, ErrSeriesNotFound))
+}
+
+func TestDB_Rename(t *testing.T) {
+	db := NewDB(filestest.TempDir(t), 10)
+	defer db.Close()
+	s, err := db.Series("temp/room1")
+	assert.Nil(t, err)
+	err = s.Append(1, []string{"some-value"})
+	assert.Nil(t, err)
+	_, err = db.Series("other")
+	assert.Nil(t, err)
+
+	err = db.Rename("temp/room1", "other")
+	assert.True(t, errors.Is(err, ErrSeriesExists))
+
+	err = db.Rename("missing", "renamed")
+	assert.True(t, errors.Is(err, ErrSeriesNotFound))
+
+	err = db.Rename("temp/room1", "kitchen/temp")
+
+	assert.Nil(t, err)
+	got, err := db.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"kitchen/temp", "other"}, got)
+	renamed, err := db.Series("kitchen/temp")
+	assert.Nil(t, err)
+	assert.Equal(t, []Point{{Timestamp: 1, Record: []string{"some-value"}}}, loadAll(t, renamed, 0, 9))
+}
+
+func TestDB_Expire(t *testing.T) {
+	db := NewDB(filestest.TempDir(t), 10, WithRetention(15))
+	defer db.Close()
+	for _, name := range []string{"a", "b"} {
+		s, err := db.Series(name)
+		assert.Nil(t, err)
+		err = s.Append(1, []string{"some-value-at-1"})
+		assert.Nil(t, err)
+		err = s.Append(35, []string{"some-value-at-35"})
+		assert.Nil(t, err)
+	}
+
+	got, err := db.Expire()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(db.root, "a", "0_9.csv"), filepath.Join(db.root, "b", "0_9.csv")}, got)
+}
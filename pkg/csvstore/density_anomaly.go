@@ -0,0 +1,14 @@
+package csvstore
+
+// DensityAnomaly is an interval of consecutive slots containing more than one
+// point each
+type DensityAnomaly struct {
+	// From is the start of the first slot
+	From uint64
+
+	// To is the end of the last slot
+	To uint64
+
+	// Points is the number of points in the slots
+	Points int
+}
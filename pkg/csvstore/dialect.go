@@ -0,0 +1,58 @@
+package csvstore
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+)
+
+// ErrInvalidDialect is returned when the dialect of the store is not valid
+var ErrInvalidDialect = errors.New("invalid dialect")
+
+// Dialect configures the format of the CSV partition files; the zero value is
+// the default format of encoding/csv
+type Dialect struct {
+	// Delimiter is the field delimiter, ',' if 0
+	Delimiter rune
+
+	// Comment, if not 0, is the character starting the comment lines, that are
+	// ignored on read, hence not preserved when a partition is rewritten. It
+	// requires the timestamps in the first column.
+	Comment rune
+
+	// LazyQuotes allows quotes in unquoted fields, and non-doubled quotes in
+	// quoted ones
+	LazyQuotes bool
+
+	// TrimLeadingSpace ignores the leading white spaces of the fields
+	TrimLeadingSpace bool
+
+	// UseCRLF terminates the written lines with \r\n instead of \n
+	UseCRLF bool
+
+	// FieldsPerRecord is the number of fields of each row, timestamp included:
+	// if 0 it is set by the first row of each file, if negative the rows can
+	// have a different number of fields
+	FieldsPerRecord int
+}
+
+func (d Dialect) newReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	if d.Delimiter != 0 {
+		reader.Comma = d.Delimiter
+	}
+	reader.Comment = d.Comment
+	reader.LazyQuotes = d.LazyQuotes
+	reader.TrimLeadingSpace = d.TrimLeadingSpace
+	reader.FieldsPerRecord = d.FieldsPerRecord
+	return reader
+}
+
+func (d Dialect) newWriter(w io.Writer) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if d.Delimiter != 0 {
+		writer.Comma = d.Delimiter
+	}
+	writer.UseCRLF = d.UseCRLF
+	return writer
+}
@@ -0,0 +1,68 @@
+package csvstore
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialect_newReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		content string
+		want    [][]string
+	}{
+		{
+			name:    "Should use the default format",
+			content: "1,a\n2,\"b,c\"\n",
+			want:    [][]string{{"1", "a"}, {"2", "b,c"}},
+		},
+		{
+			name:    "Should use the configured format",
+			dialect: Dialect{Delimiter: ';', Comment: '#', LazyQuotes: true, TrimLeadingSpace: true, FieldsPerRecord: -1},
+			content: "# comment\n1; a\"b\r\n2;b;c\n",
+			want:    [][]string{{"1", "a\"b"}, {"2", "b", "c"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.dialect.newReader(strings.NewReader(tt.content)).ReadAll()
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDialect_newWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{
+			name: "Should use the default format",
+			want: "1,a;b\n",
+		},
+		{
+			name:    "Should use the configured format",
+			dialect: Dialect{Delimiter: ';', UseCRLF: true},
+			want:    "1;\"a;b\"\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			writer := tt.dialect.newWriter(buffer)
+
+			err := writer.Write([]string{"1", "a;b"})
+			assert.Nil(t, err)
+			writer.Flush()
+
+			assert.Equal(t, tt.want, buffer.String())
+		})
+	}
+}
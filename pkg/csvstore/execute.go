@@ -0,0 +1,124 @@
+package csvstore
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Execute runs the statement on the store, ignoring its series, calling the
+// handler with the timestamp and the values of each row: the columns
+// selected, or the aggregates formatted as numbers, empty if undefined. The
+// time range is the one of the points in the store if not bounded by the
+// statement.
+func (s *Store) Execute(stmt *Statement, handler func(uint64, []string) error) error {
+	from, to, ok, err := s.statementRange(stmt)
+	if err != nil || !ok {
+		return err
+	}
+
+	rows := 0
+	limited := func(timestamp uint64, record []string) error {
+		err := handler(timestamp, record)
+		if err != nil {
+			return err
+		}
+		rows++
+		if stmt.Limit > 0 && rows >= stmt.Limit {
+			return errStopLoading
+		}
+		return nil
+	}
+
+	if len(stmt.Aggregates) == 0 {
+		_, err = s.Query(Query{From: from, To: to, Names: stmt.Columns, Where: stmt.Where}, limited)
+	} else {
+		q := AggregateQuery{From: from, To: to, Where: stmt.Where, Aggregates: stmt.Aggregates, Step: s.statementStep(stmt)}
+		if q.Step == 0 && (stmt.step > 0 || stmt.stepUnits > 0) {
+			return ErrInvalidStep
+		}
+		_, err = s.Aggregate(q, func(timestamp uint64, values []float64) error {
+			record := make([]string, len(values))
+			for i, v := range values {
+				if !math.IsNaN(v) {
+					record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+				}
+			}
+			return limited(timestamp, record)
+		})
+	}
+
+	if errors.Is(err, errStopLoading) {
+		return nil
+	}
+	return err
+}
+
+// statementRange returns the range of the timestamps selected by the
+// statement, false if empty
+func (s *Store) statementRange(stmt *Statement) (uint64, uint64, bool, error) {
+	from, to := uint64(0), uint64(math.MaxUint64)
+	fromSet, toSet := false, false
+	for _, bound := range stmt.bounds {
+		timestamp, err := bound.resolve(s)
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		if bound.op == Greater || bound.op == GreaterOrEqual || bound.op == Equal {
+			if bound.op == Greater {
+				if timestamp == math.MaxUint64 {
+					return 0, 0, false, nil
+				}
+				timestamp++
+			}
+			if !fromSet || timestamp > from {
+				from = timestamp
+			}
+			fromSet = true
+		}
+		if bound.op == Less || bound.op == LessOrEqual || bound.op == Equal {
+			if bound.op == Less {
+				if timestamp == 0 {
+					return 0, 0, false, nil
+				}
+				timestamp--
+			}
+			if !toSet || timestamp < to {
+				to = timestamp
+			}
+			toSet = true
+		}
+	}
+
+	if !fromSet {
+		earliest, found, err := s.earliestTimestamp()
+		if err != nil || !found {
+			return 0, 0, false, err
+		}
+		from = earliest
+	}
+	if !toSet {
+		last, record, err := s.LastPoint()
+		if err != nil || record == nil {
+			return 0, 0, false, err
+		}
+		to = last
+	}
+
+	return from, to, from <= to, nil
+}
+
+// statementStep returns the step of GROUP BY in the unit of the store, 0 if
+// not grouped or shorter than the unit
+func (s *Store) statementStep(stmt *Statement) uint64 {
+	if stmt.stepUnits > 0 {
+		return stmt.stepUnits
+	}
+	unit := time.Duration(s.unit)
+	if unit == 0 {
+		unit = time.Second
+	}
+	return uint64(stmt.step / unit)
+}
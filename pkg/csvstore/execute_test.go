@@ -0,0 +1,99 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Execute(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []Point
+		wantErr error
+	}{
+		{
+			name:  "Should return all the points",
+			query: "SELECT * FROM trades",
+			want: []Point{
+				{Timestamp: 1, Record: []string{"X", "50"}},
+				{Timestamp: 2, Record: []string{"Y", "150"}},
+				{Timestamp: 11, Record: []string{"X", "20"}},
+				{Timestamp: 12, Record: []string{"X", ""}},
+				{Timestamp: 21, Record: []string{"Y", "200"}},
+				{Timestamp: 35, Record: []string{"Y", "n/a"}},
+			},
+		},
+		{
+			name:  "Should return the columns of the points in the time range matching the condition",
+			query: "SELECT price FROM trades WHERE time >= 2 AND time < 21 AND symbol = 'X'",
+			want: []Point{
+				{Timestamp: 11, Record: []string{"20"}},
+				{Timestamp: 12, Record: []string{""}},
+			},
+		},
+		{
+			name:  "Should return the aggregates of the time buckets",
+			query: "SELECT count(*), max(price) FROM trades GROUP BY time(10s)",
+			want: []Point{
+				{Timestamp: 0, Record: []string{"2", "150"}},
+				{Timestamp: 10, Record: []string{"2", "20"}},
+				{Timestamp: 20, Record: []string{"1", "200"}},
+				{Timestamp: 30, Record: []string{"1", ""}},
+			},
+		},
+		{
+			name:  "Should return the aggregates of the whole range",
+			query: "SELECT count(*), mean(price) FROM trades WHERE symbol == 'X'",
+			want:  []Point{{Timestamp: 0, Record: []string{"3", "35"}}},
+		},
+		{
+			name:  "Should stop at the limit",
+			query: "SELECT symbol FROM trades WHERE price > 30 LIMIT 2",
+			want: []Point{
+				{Timestamp: 1, Record: []string{"X"}},
+				{Timestamp: 2, Record: []string{"Y"}},
+			},
+		},
+		{
+			name:  "Should return nothing if the time range is empty",
+			query: "SELECT * FROM trades WHERE time > 10 AND time <= 10",
+		},
+		{
+			name:    "Should return error if the step is shorter than the unit of the store",
+			query:   "SELECT count(*) FROM trades GROUP BY time(500ms)",
+			wantErr: ErrInvalidStep,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAggregateTestStore(t)
+			stmt, err := ParseStatement(tt.query)
+			assert.Nil(t, err)
+
+			var got []Point
+			err = s.Execute(stmt, func(timestamp uint64, record []string) error {
+				got = append(got, Point{Timestamp: timestamp, Record: append([]string(nil), record...)})
+				return nil
+			})
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStore_Execute_ShouldReturnNothingIfEmpty(t *testing.T) {
+	s := NewStore(filestest.TempDir(t), 10)
+	stmt, err := ParseStatement("SELECT count(*) FROM empty")
+	assert.Nil(t, err)
+
+	err = s.Execute(stmt, func(uint64, []string) error {
+		assert.Fail(t, "Unexpected row")
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
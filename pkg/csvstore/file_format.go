@@ -0,0 +1,40 @@
+package csvstore
+
+import "fmt"
+
+// fileFormat contains the configuration needed to read and write the CSV
+// partition files
+type fileFormat struct {
+	dialect    Dialect
+	timestamps timestampCodec
+}
+
+// fileFormat returns the format of the CSV partition files of the store
+func (s *Store) fileFormat() fileFormat {
+	return fileFormat{
+		dialect: s.dialect,
+		timestamps: timestampCodec{
+			column: s.timestamps.Column,
+			layout: s.timestamps.Layout,
+			unit:   s.unit,
+			signed: s.index.signed,
+		},
+	}
+}
+
+// validateFormat returns an error if the format of the CSV partition files is
+// not valid
+func (s *Store) validateFormat() error {
+	err := s.timestamps.validate(s.unit)
+	if err != nil {
+		return err
+	}
+
+	// a first field starting with the comment character would be written
+	// unquoted, and skipped on read
+	if s.dialect.Comment != 0 && s.timestamps.Column != 0 {
+		return fmt.Errorf("%w: comments require the timestamps in the first column", ErrInvalidDialect)
+	}
+
+	return nil
+}
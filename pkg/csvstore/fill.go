@@ -0,0 +1,34 @@
+package csvstore
+
+// FillMethod defines how LoadGrid fills the grid points without a stored
+// point at the same timestamp
+type FillMethod int
+
+const (
+	// FillNull emits a nil record
+	FillNull FillMethod = iota
+	// FillLinear interpolates linearly the numeric columns of the previous and
+	// next points, and uses the previous value for the others
+	FillLinear
+	// FillPrevious uses the record of the previous point
+	FillPrevious
+	// FillNext uses the record of the next point
+	FillNext
+	// FillConstant uses a constant record
+	FillConstant
+)
+
+// Fill configures how LoadGrid fills the grid points
+type Fill struct {
+	// Method is the fill method
+	Method FillMethod
+
+	// Value is the record used by FillConstant
+	Value []string
+
+	// MaxGap is the maximum distance between the points surrounding a grid
+	// point for it to be filled, or, for FillPrevious and FillNext, between
+	// the grid point and the one used; nil is emitted beyond it. It is not
+	// limited if 0.
+	MaxGap uint64
+}
@@ -0,0 +1,10 @@
+package csvstore
+
+// Gap is an interval of timestamps without the expected points
+type Gap struct {
+	// From is the start of the first missing slot
+	From uint64
+
+	// To is the end of the last missing slot
+	To uint64
+}
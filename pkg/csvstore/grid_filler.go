@@ -0,0 +1,109 @@
+package csvstore
+
+import "strconv"
+
+// gridFiller emits the points of a regular grid, filling them from the
+// surrounding stored points, received in timestamp order
+type gridFiller struct {
+	next    uint64
+	to      uint64
+	step    uint64
+	done    bool
+	fill    Fill
+	prev    *dataPoint
+	handler func(uint64, []string) error
+}
+
+// add emits the grid points up to the stored point
+func (f *gridFiller) add(p *dataPoint) error {
+	for !f.done && f.next < p.timestamp {
+		err := f.emit(f.fill.record(f.next, f.prev, p))
+		if err != nil {
+			return err
+		}
+	}
+	if !f.done && f.next == p.timestamp {
+		err := f.emit(p.record)
+		if err != nil {
+			return err
+		}
+	}
+
+	f.prev = p
+	return nil
+}
+
+// finish emits the remaining grid points, without a next point
+func (f *gridFiller) finish() error {
+	for !f.done {
+		err := f.emit(f.fill.record(f.next, f.prev, nil))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emit calls the handler for the current grid point, and moves to the next
+func (f *gridFiller) emit(record []string) error {
+	err := f.handler(f.next, record)
+	if f.to-f.next < f.step {
+		f.done = true
+	} else {
+		f.next += f.step
+	}
+	return err
+}
+
+// record returns the record filling the timestamp between prev and next,
+// which can be nil
+func (f Fill) record(timestamp uint64, prev *dataPoint, next *dataPoint) []string {
+	switch f.Method {
+	case FillPrevious:
+		if prev != nil && f.within(prev.timestamp, timestamp) {
+			return prev.record
+		}
+	case FillNext:
+		if next != nil && f.within(timestamp, next.timestamp) {
+			return next.record
+		}
+	case FillLinear:
+		if prev != nil && next != nil && f.within(prev.timestamp, next.timestamp) {
+			return interpolate(prev, next, timestamp)
+		}
+	case FillConstant:
+		if f.MaxGap == 0 || prev != nil && next != nil && f.within(prev.timestamp, next.timestamp) {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+// within returns true if the distance between from and to is not greater
+// than the max gap
+func (f Fill) within(from uint64, to uint64) bool {
+	return f.MaxGap == 0 || to-from <= f.MaxGap
+}
+
+// interpolate returns the record at the timestamp interpolating linearly the
+// numeric columns of the points, and using the values of prev for the others
+func interpolate(prev *dataPoint, next *dataPoint, timestamp uint64) []string {
+	ratio := float64(timestamp-prev.timestamp) / float64(next.timestamp-prev.timestamp)
+	record := make([]string, len(prev.record))
+	for i, value := range prev.record {
+		record[i] = value
+		if i >= len(next.record) {
+			continue
+		}
+		from, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		to, err := strconv.ParseFloat(next.record[i], 64)
+		if err != nil {
+			continue
+		}
+		record[i] = strconv.FormatFloat(from+(to-from)*ratio, 'f', -1, 64)
+	}
+	return record
+}
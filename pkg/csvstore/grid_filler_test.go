@@ -0,0 +1,16 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_interpolate(t *testing.T) {
+	prev := &dataPoint{timestamp: 0, record: []string{"1", "a", "-2.5", "x"}}
+	next := &dataPoint{timestamp: 4, record: []string{"2", "b", "2.5"}}
+
+	got := interpolate(prev, next, 1)
+
+	assert.Equal(t, []string{"1.25", "a", "-1.25", "x"}, got)
+}
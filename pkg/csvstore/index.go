@@ -2,16 +2,17 @@ package csvstore
 
 type index struct {
 	interval uint64
+	signed   bool
 }
 
 func (i *index) findDataset(timestamp uint64) string {
-	from, to := timestampToInterval(timestamp, i.interval)
-	return datasetName(from, to)
+	from, to := timestampToInterval(timestamp, i.interval, i.signed)
+	return datasetName(from, to, i.signed)
 }
 
 func (i *index) findDatasets(from uint64, to uint64) []string {
-	startFrom, _ := timestampToInterval(from, i.interval)
-	_, endTo := timestampToInterval(to, i.interval)
+	startFrom, _ := timestampToInterval(from, i.interval, i.signed)
+	_, endTo := timestampToInterval(to, i.interval, i.signed)
 
 	count := (endTo - startFrom + 1) / i.interval
 
@@ -20,7 +21,7 @@ func (i *index) findDatasets(from uint64, to uint64) []string {
 	currentFrom := startFrom
 	for j := uint64(0); j < count; j++ {
 		currentTo := currentFrom + i.interval
-		result[j] = datasetName(currentFrom, currentTo-1)
+		result[j] = datasetName(currentFrom, currentTo-1, i.signed)
 		currentFrom = currentTo
 	}
 
@@ -4,7 +4,7 @@ import (
 	"github.com/pasdam/go-search/pkg/search"
 )
 
-func insert(timestamp uint64, record []string, points *dataPointList) {
+func insert(timestamp uint64, record []string, points *dataPointList, policy ConflictPolicy) (InsertStats, error) {
 	point := &dataPoint{
 		timestamp: timestamp,
 		record:    record,
@@ -12,22 +12,37 @@ func insert(timestamp uint64, record []string, points *dataPointList) {
 
 	index, found := search.BinarySearch(newDatasetComparator(timestamp), *points)
 	if found {
+		if policy == nil {
+			policy = ReplaceOnConflict
+		}
+
+		resolved, err := policy(timestamp, (*points)[index].record, record)
+		if err != nil {
+			return InsertStats{}, err
+		}
+		if resolved == nil {
+			return InsertStats{Skipped: 1}, nil
+		}
+
 		// replace
+		point.record = resolved
 		(*points)[index] = point
+		return InsertStats{Replaced: 1}, nil
+	}
 
-	} else {
-		// insert
-		pointsSlice := *points
-		last := len(pointsSlice) - 1
-		if last >= 0 {
-			pointsSlice = append(pointsSlice, pointsSlice[last]) // extend array
-			if index <= last {
-				copy(pointsSlice[index+1:], pointsSlice[index:last]) // shift elements
-			}
-		} else {
-			pointsSlice = append(pointsSlice, nil) // extend array
+	// insert
+	pointsSlice := *points
+	last := len(pointsSlice) - 1
+	if last >= 0 {
+		pointsSlice = append(pointsSlice, pointsSlice[last]) // extend array
+		if index <= last {
+			copy(pointsSlice[index+1:], pointsSlice[index:last]) // shift elements
 		}
-		pointsSlice[index] = point // insert element
-		*points = pointsSlice
+	} else {
+		pointsSlice = append(pointsSlice, nil) // extend array
 	}
+	pointsSlice[index] = point // insert element
+	*points = pointsSlice
+
+	return InsertStats{Inserted: 1}, nil
 }
@@ -0,0 +1,20 @@
+package csvstore
+
+// InsertStats contains the number of data points inserted, replaced or
+// skipped while storing a time series
+type InsertStats struct {
+	// Inserted is the number of new data points
+	Inserted int
+
+	// Replaced is the number of data points that replaced existing ones
+	Replaced int
+
+	// Skipped is the number of data points discarded because of a conflict
+	Skipped int
+}
+
+func (s *InsertStats) add(other InsertStats) {
+	s.Inserted += other.Inserted
+	s.Replaced += other.Replaced
+	s.Skipped += other.Skipped
+}
@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertStats_add(t *testing.T) {
+	s := InsertStats{Inserted: 1, Replaced: 2, Skipped: 3}
+
+	s.add(InsertStats{Inserted: 10, Replaced: 20, Skipped: 30})
+
+	assert.Equal(t, InsertStats{Inserted: 11, Replaced: 22, Skipped: 33}, s)
+}
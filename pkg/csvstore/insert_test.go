@@ -1,6 +1,7 @@
 package csvstore
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,11 +12,14 @@ func Test_insert(t *testing.T) {
 		timestamp uint64
 		record    []string
 		points    dataPointList
+		policy    ConflictPolicy
 	}
 	tests := []struct {
-		name string
-		args args
-		want dataPointList
+		name      string
+		args      args
+		want      dataPointList
+		wantStats InsertStats
+		wantErr   error
 	}{
 		{
 			name: "Should replace existing point",
@@ -33,6 +37,7 @@ func Test_insert(t *testing.T) {
 				{timestamp: 1, record: []string{"some-other-value-at-1"}},
 				{timestamp: 2, record: []string{"some-value-at-2"}},
 			},
+			wantStats: InsertStats{Replaced: 1},
 		},
 		{
 			name: "Should insert as first element if array is empty",
@@ -44,6 +49,7 @@ func Test_insert(t *testing.T) {
 			want: []*dataPoint{
 				{timestamp: 0, record: []string{"some-value-at-0"}},
 			},
+			wantStats: InsertStats{Inserted: 1},
 		},
 		{
 			name: "Should insert as first element, when array has already other greater elements",
@@ -62,6 +68,7 @@ func Test_insert(t *testing.T) {
 				{timestamp: 2, record: []string{"some-value-at-2"}},
 				{timestamp: 3, record: []string{"some-value-at-3"}},
 			},
+			wantStats: InsertStats{Inserted: 1},
 		},
 		{
 			name: "Should insert as second element",
@@ -80,6 +87,7 @@ func Test_insert(t *testing.T) {
 				{timestamp: 2, record: []string{"some-value-at-2"}},
 				{timestamp: 3, record: []string{"some-value-at-3"}},
 			},
+			wantStats: InsertStats{Inserted: 1},
 		},
 		{
 			name: "Should insert as second element",
@@ -98,12 +106,82 @@ func Test_insert(t *testing.T) {
 				{timestamp: 2, record: []string{"some-value-at-2"}},
 				{timestamp: 3, record: []string{"some-value-at-3"}},
 			},
+			wantStats: InsertStats{Inserted: 1},
+		},
+		{
+			name: "Should keep existing point if policy returns nil",
+			args: args{
+				timestamp: 1,
+				record:    []string{"some-other-value-at-1"},
+				points: []*dataPoint{
+					{timestamp: 0, record: []string{"some-value-at-0"}},
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+				policy: KeepOnConflict,
+			},
+			want: []*dataPoint{
+				{timestamp: 0, record: []string{"some-value-at-0"}},
+				{timestamp: 1, record: []string{"some-value-at-1"}},
+			},
+			wantStats: InsertStats{Skipped: 1},
+		},
+		{
+			name: "Should store the record returned by the policy",
+			args: args{
+				timestamp: 1,
+				record:    []string{"some-other-value-at-1"},
+				points: []*dataPoint{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+				policy: func(timestamp uint64, existing []string, incoming []string) ([]string, error) {
+					return append(existing, incoming...), nil
+				},
+			},
+			want: []*dataPoint{
+				{timestamp: 1, record: []string{"some-value-at-1", "some-other-value-at-1"}},
+			},
+			wantStats: InsertStats{Replaced: 1},
+		},
+		{
+			name: "Should return error if policy raises it",
+			args: args{
+				timestamp: 1,
+				record:    []string{"some-other-value-at-1"},
+				points: []*dataPoint{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+				policy: func(timestamp uint64, existing []string, incoming []string) ([]string, error) {
+					return nil, errors.New("some-policy-error")
+				},
+			},
+			want: []*dataPoint{
+				{timestamp: 1, record: []string{"some-value-at-1"}},
+			},
+			wantErr: errors.New("some-policy-error"),
+		},
+		{
+			name: "Should not call policy if there is no conflict",
+			args: args{
+				timestamp: 1,
+				record:    []string{"some-value-at-1"},
+				points: []*dataPoint{
+					{timestamp: 0, record: []string{"some-value-at-0"}},
+				},
+				policy: ErrorOnConflict,
+			},
+			want: []*dataPoint{
+				{timestamp: 0, record: []string{"some-value-at-0"}},
+				{timestamp: 1, record: []string{"some-value-at-1"}},
+			},
+			wantStats: InsertStats{Inserted: 1},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			insert(tt.args.timestamp, tt.args.record, &tt.args.points)
+			stats, err := insert(tt.args.timestamp, tt.args.record, &tt.args.points, tt.args.policy)
 
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantStats, stats)
 			assert.Equal(t, len(tt.want), len(tt.args.points))
 			assert.Equal(t, tt.want, tt.args.points)
 		})
@@ -0,0 +1,12 @@
+package csvstore
+
+import (
+	"strings"
+)
+
+// isDatasetFile returns true if the name has the extension of a partition
+// file, either CSV (plain or compressed) or columnar
+func isDatasetFile(name string) bool {
+	name = strings.TrimSuffix(name, compressionOf(name).suffix())
+	return strings.HasSuffix(name, ".csv") || isColumnar(name)
+}
@@ -0,0 +1,29 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isDatasetFile(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "0_9.csv", want: true},
+		{name: "0_9.csv.gz", want: true},
+		{name: "0_9.csv.zst", want: true},
+		{name: "0_9.col", want: true},
+		{name: "0_9.txt", want: false},
+		{name: "0_9.gz", want: false},
+		{name: "csvstore.json", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isDatasetFile(tt.name)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
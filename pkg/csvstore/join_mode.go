@@ -0,0 +1,16 @@
+package csvstore
+
+// JoinMode defines how LoadMergedPoints aligns the points of the stores
+type JoinMode int
+
+const (
+	// InterleaveRows yields each point in its own row, in timestamp order
+	InterleaveRows JoinMode = iota
+	// OuterJoin yields a row for each distinct timestamp, with the points of
+	// all the stores having one at that timestamp, and nil for the others
+	OuterJoin
+	// AsOfJoin is like OuterJoin, but the stores without a point at the
+	// timestamp carry their last known record, if any, including the one
+	// before the range
+	AsOfJoin
+)
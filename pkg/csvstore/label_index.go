@@ -0,0 +1,101 @@
+package csvstore
+
+import "sort"
+
+// labelIndex is an inverted index from label name and value to the names of
+// the series having it
+type labelIndex struct {
+	labels   map[string]Labels
+	postings map[string]map[string]map[string]struct{}
+}
+
+func newLabelIndex() *labelIndex {
+	return &labelIndex{
+		labels:   make(map[string]Labels),
+		postings: make(map[string]map[string]map[string]struct{}),
+	}
+}
+
+// add indexes the series with the labels, replacing its previous ones
+func (i *labelIndex) add(series string, labels Labels) {
+	i.remove(series)
+
+	i.labels[series] = labels
+	for name, value := range labels {
+		values, ok := i.postings[name]
+		if !ok {
+			values = make(map[string]map[string]struct{})
+			i.postings[name] = values
+		}
+		names, ok := values[value]
+		if !ok {
+			names = make(map[string]struct{})
+			values[value] = names
+		}
+		names[series] = struct{}{}
+	}
+}
+
+// remove removes the series from the index
+func (i *labelIndex) remove(series string) {
+	for name, value := range i.labels[series] {
+		names := i.postings[name][value]
+		delete(names, series)
+		if len(names) == 0 {
+			delete(i.postings[name], value)
+		}
+		if len(i.postings[name]) == 0 {
+			delete(i.postings, name)
+		}
+	}
+	delete(i.labels, series)
+}
+
+// selectSeries returns the sorted names of the series matching all the
+// matchers. The equality matchers with a non empty value are resolved with
+// the postings, the others by checking the labels of the candidate series.
+func (i *labelIndex) selectSeries(matchers []*Matcher) []string {
+	var candidates map[string]struct{}
+	for _, m := range matchers {
+		if m.Type != MatchEqual || len(m.Value) == 0 {
+			continue
+		}
+		names := i.postings[m.Name][m.Value]
+		if candidates == nil {
+			candidates = make(map[string]struct{}, len(names))
+			for name := range names {
+				candidates[name] = struct{}{}
+			}
+			continue
+		}
+		for name := range candidates {
+			if _, ok := names[name]; !ok {
+				delete(candidates, name)
+			}
+		}
+	}
+	if candidates == nil {
+		candidates = make(map[string]struct{}, len(i.labels))
+		for name := range i.labels {
+			candidates[name] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(candidates))
+	for name := range candidates {
+		labels := i.labels[name]
+		matches := true
+		for _, m := range matchers {
+			if !m.Matches(labels[m.Name]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,77 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_labelIndex_selectSeries(t *testing.T) {
+	mustMatcher := func(t MatchType, name string, value string) *Matcher {
+		m, _ := NewMatcher(t, name, value)
+		return m
+	}
+	tests := []struct {
+		name     string
+		matchers []*Matcher
+		want     []string
+	}{
+		{
+			name: "Should return all series if there are no matchers",
+			want: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:     "Should return series with equal labels",
+			matchers: []*Matcher{mustMatcher(MatchEqual, "region", "eu"), mustMatcher(MatchEqual, "host", "a")},
+			want:     []string{"a"},
+		},
+		{
+			name:     "Should return series without the label if equal to empty value",
+			matchers: []*Matcher{mustMatcher(MatchEqual, "region", "")},
+			want:     []string{"d"},
+		},
+		{
+			name:     "Should return series not matching negated matchers",
+			matchers: []*Matcher{mustMatcher(MatchEqual, "region", "eu"), mustMatcher(MatchNotEqual, "host", "a")},
+			want:     []string{"b"},
+		},
+		{
+			name:     "Should return series matching regular expressions",
+			matchers: []*Matcher{mustMatcher(MatchRegexp, "region", "eu|us")},
+			want:     []string{"a", "b", "c"},
+		},
+		{
+			name:     "Should return series not matching negated regular expressions",
+			matchers: []*Matcher{mustMatcher(MatchNotRegexp, "host", "a|b")},
+			want:     []string{"d"},
+		},
+		{
+			name:     "Should return nothing if no series has the label value",
+			matchers: []*Matcher{mustMatcher(MatchEqual, "region", "asia")},
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := newLabelIndex()
+			i.add("a", Labels{"host": "a", "region": "eu"})
+			i.add("b", Labels{"host": "b", "region": "eu"})
+			i.add("c", Labels{"host": "a", "region": "us"})
+			i.add("d", nil)
+
+			assert.Equal(t, tt.want, i.selectSeries(tt.matchers))
+		})
+	}
+}
+
+func Test_labelIndex_remove(t *testing.T) {
+	i := newLabelIndex()
+	i.add("a", Labels{"host": "a"})
+	i.add("b", Labels{"host": "a"})
+	i.add("a", Labels{"host": "b"})
+
+	i.remove("b")
+
+	assert.Equal(t, map[string]Labels{"a": {"host": "b"}}, i.labels)
+	assert.Equal(t, map[string]map[string]map[string]struct{}{"host": {"b": {"a": {}}}}, i.postings)
+}
@@ -0,0 +1,39 @@
+package csvstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Labels are the attributes of a series, e.g. host=a, region=eu, used to
+// select it
+type Labels map[string]string
+
+// String returns the labels sorted by name, e.g. {host="a", region="eu"}
+func (l Labels) String() string {
+	names := make([]string, 0, len(l))
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%q", name, l[name])
+	}
+
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// clone returns a copy of the labels, nil if empty
+func (l Labels) clone() Labels {
+	if len(l) == 0 {
+		return nil
+	}
+	result := make(Labels, len(l))
+	for name, value := range l {
+		result[name] = value
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabels_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels Labels
+		want   string
+	}{
+		{
+			name: "Should return empty braces if there are no labels",
+			want: "{}",
+		},
+		{
+			name:   "Should return labels sorted by name",
+			labels: Labels{"region": "eu", "host": "a"},
+			want:   `{host="a", region="eu"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.labels.String())
+		})
+	}
+}
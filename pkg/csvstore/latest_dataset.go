@@ -4,7 +4,7 @@ import (
 	"io/ioutil"
 )
 
-func latestDataset(dir string) (string, error) {
+func latestDataset(dir string, signed bool) (string, error) {
 	infos, err := ioutil.ReadDir(dir)
 	if err != nil {
 		return "", err
@@ -14,12 +14,16 @@ func latestDataset(dir string) (string, error) {
 	var latestTo uint64
 	for _, info := range infos {
 		currentName := info.Name()
-		_, currentTo, err := parseDatasetName(currentName)
+		if info.IsDir() || !isDatasetFile(currentName) {
+			continue
+		}
+
+		_, currentTo, err := parseDatasetName(currentName, signed)
 		if err != nil {
 			return "", err
 		}
 
-		if currentTo > latestTo {
+		if latestName == "" || currentTo > latestTo {
 			latestName = currentName
 			latestTo = currentTo
 		}
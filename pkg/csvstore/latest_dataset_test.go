@@ -43,7 +43,7 @@ func Test_latestDataset(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := latestDataset(tt.args.dir)
+			got, err := latestDataset(tt.args.dir, false)
 
 			if tt.wantErr != nil {
 				assert.NotNil(t, err)
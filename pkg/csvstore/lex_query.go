@@ -0,0 +1,86 @@
+package csvstore
+
+import (
+	"strings"
+	"unicode"
+)
+
+// lexQuery splits the statement into tokens
+func lexQuery(statement string) ([]qlToken, error) {
+	var tokens []qlToken
+	runes := []rune(statement)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+
+		case qlPunctuation[r] != qlEOF:
+			tokens = append(tokens, qlToken{kind: qlPunctuation[r], text: string(r), pos: start + 1})
+			i++
+			continue
+
+		case r == '\'' || r == '"':
+			var value strings.Builder
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, &SyntaxError{Pos: start + 1, Msg: "unterminated string"}
+				}
+				if runes[i] == r {
+					// a doubled quote is an escaped quote
+					if i+1 < len(runes) && runes[i+1] == r {
+						value.WriteRune(r)
+						i++
+						continue
+					}
+					break
+				}
+				value.WriteRune(runes[i])
+			}
+			i++
+			tokens = append(tokens, qlToken{kind: qlString, text: value.String(), pos: start + 1})
+			continue
+
+		case strings.ContainsRune("=!<>", r):
+			i++
+			if i < len(runes) && (runes[i] == '=' || r == '<' && runes[i] == '>') {
+				i++
+			}
+			text := string(runes[start:i])
+			if text == "!" {
+				return nil, &SyntaxError{Pos: start + 1, Msg: `unexpected "!"`}
+			}
+			tokens = append(tokens, qlToken{kind: qlOperator, text: text, pos: start + 1})
+			continue
+
+		case unicode.IsDigit(r) || (r == '-' || r == '.') && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			// numbers can have a unit suffix, e.g. 1h
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || unicode.IsLetter(runes[i])); i++ {
+			}
+			tokens = append(tokens, qlToken{kind: qlNumber, text: string(runes[start:i]), pos: start + 1})
+			continue
+
+		case unicode.IsLetter(r) || r == '_':
+			for i++; i < len(runes) && isIdentRune(runes[i]); i++ {
+			}
+			tokens = append(tokens, qlToken{kind: qlIdent, text: string(runes[start:i]), pos: start + 1})
+			continue
+		}
+
+		return nil, &SyntaxError{Pos: start + 1, Msg: "unexpected character " + quoteRune(r)}
+	}
+
+	return append(tokens, qlToken{kind: qlEOF, pos: len(runes) + 1}), nil
+}
+
+// isIdentRune returns true if the rune can be part of an identifier, which
+// can contain slashes, dots and dashes to name nested series
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_./-", r)
+}
+
+func quoteRune(r rune) string {
+	return "\"" + string(r) + "\""
+}
@@ -0,0 +1,61 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lexQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      []qlToken
+		wantErr   error
+	}{
+		{
+			name:      "Should split identifiers, punctuation and operators",
+			statement: "SELECT max(c1),* FROM s WHERE c2<>'a'",
+			want: []qlToken{
+				{kind: qlIdent, text: "SELECT", pos: 1},
+				{kind: qlIdent, text: "max", pos: 8},
+				{kind: qlLeftParen, text: "(", pos: 11},
+				{kind: qlIdent, text: "c1", pos: 12},
+				{kind: qlRightParen, text: ")", pos: 14},
+				{kind: qlComma, text: ",", pos: 15},
+				{kind: qlStar, text: "*", pos: 16},
+				{kind: qlIdent, text: "FROM", pos: 18},
+				{kind: qlIdent, text: "s", pos: 23},
+				{kind: qlIdent, text: "WHERE", pos: 25},
+				{kind: qlIdent, text: "c2", pos: 31},
+				{kind: qlOperator, text: "<>", pos: 33},
+				{kind: qlString, text: "a", pos: 35},
+				{kind: qlEOF, pos: 38},
+			},
+		},
+		{
+			name:      "Should parse numbers, durations and escaped quotes",
+			statement: `-1.5 1h "it""s" temp/room-1`,
+			want: []qlToken{
+				{kind: qlNumber, text: "-1.5", pos: 1},
+				{kind: qlNumber, text: "1h", pos: 6},
+				{kind: qlString, text: `it"s`, pos: 9},
+				{kind: qlIdent, text: "temp/room-1", pos: 17},
+				{kind: qlEOF, pos: 28},
+			},
+		},
+		{
+			name:      "Should return error if a string is not terminated",
+			statement: "c = 'a",
+			wantErr:   &SyntaxError{Pos: 5, Msg: "unterminated string"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lexQuery(tt.statement)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package csvstore
+
+import (
+	"io/ioutil"
+	"sort"
+)
+
+// listDatasets returns the names of the partition files in the folder, sorted
+// by interval
+func listDatasets(dir string, signed bool) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(infos))
+	froms := make(map[string]uint64, len(infos))
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !isDatasetFile(name) {
+			continue
+		}
+
+		from, _, err := parseDatasetName(name, signed)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+		froms[name] = from
+	}
+
+	sort.SliceStable(names, func(i, j int) bool { return froms[names[i]] < froms[names[j]] })
+
+	return names, nil
+}
@@ -0,0 +1,56 @@
+package csvstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/pasdam/go-io-utilx/pkg/ioutilx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_listDatasets(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "Should return partition files sorted by interval",
+			files: []string{"20_29.col", "100_109.csv", "0_9.csv.gz", "some-file.txt"},
+			want:  []string{"0_9.csv.gz", "20_29.col", "100_109.csv"},
+		},
+		{
+			name:    "Should return error if a partition name is invalid",
+			files:   []string{"0_9.csv", "invalid.csv"},
+			wantErr: errors.New("Wrong file name format: invalid.csv"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filestest.TempDir(t)
+			for _, name := range tt.files {
+				err := ioutilx.ReaderToFile(strings.NewReader(""), filepath.Join(dir, name))
+				assert.Nil(t, err)
+			}
+			err := os.Mkdir(filepath.Join(dir, "30_39.csv"), os.ModePerm)
+			assert.Nil(t, err)
+
+			got, err := listDatasets(dir, false)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_listDatasets_ShouldReturnErrorIfFolderDoesNotExist(t *testing.T) {
+	got, err := listDatasets("some-not-existing-folder", false)
+
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, got)
+}
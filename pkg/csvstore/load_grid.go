@@ -0,0 +1,80 @@
+package csvstore
+
+import (
+	"errors"
+	"math"
+	"os"
+)
+
+// ErrInvalidStep is returned by LoadGrid if the step is 0
+var ErrInvalidStep = errors.New("invalid step")
+
+// errStopLoading is used to stop loading points once the needed ones have
+// been read
+var errStopLoading = errors.New("stop loading")
+
+// LoadGrid loads the points of a regular grid between from and to, every
+// step, calling the handler for each of them. The grid points matching a
+// stored point get its record, the others are filled from the surrounding
+// stored points, also outside the range, according to the fill.
+func (s *Store) LoadGrid(from uint64, to uint64, step uint64, fill Fill, handler func(uint64, []string) error) error {
+	if step == 0 {
+		return ErrInvalidStep
+	}
+	if to < from {
+		return nil
+	}
+
+	filler := &gridFiller{next: from, to: to, step: step, fill: fill, handler: handler}
+	if from > 0 && (fill.Method == FillPrevious || fill.Method == FillLinear || fill.Method == FillConstant) {
+		timestamp, record, err := s.PointAt(from-1, fill.MaxGap)
+		if err != nil {
+			return err
+		}
+		if record != nil {
+			filler.prev = &dataPoint{timestamp: timestamp, record: record}
+		}
+	}
+
+	end, err := s.gridLookahead(to, fill)
+	if err != nil {
+		return err
+	}
+
+	err = s.LoadPoints(from, end, func(timestamp uint64, record []string) error {
+		err := filler.add(&dataPoint{timestamp: timestamp, record: record})
+		if err == nil && timestamp >= to {
+			return errStopLoading
+		}
+		return err
+	})
+	if err != nil && err != errStopLoading {
+		return err
+	}
+
+	return filler.finish()
+}
+
+// gridLookahead returns the timestamp up to which the points are loaded to
+// find the one following the end of the grid
+func (s *Store) gridLookahead(to uint64, fill Fill) (uint64, error) {
+	if fill.Method == FillNull || fill.Method == FillPrevious || fill.Method == FillConstant && fill.MaxGap == 0 {
+		return to, nil
+	}
+
+	if fill.MaxGap > 0 {
+		if math.MaxUint64-to < fill.MaxGap {
+			return math.MaxUint64, nil
+		}
+		return to + fill.MaxGap, nil
+	}
+
+	last, record, err := s.LastPoint()
+	if err != nil && !os.IsNotExist(err) {
+		return to, err
+	}
+	if record == nil || last < to {
+		return to, nil
+	}
+	return last, nil
+}
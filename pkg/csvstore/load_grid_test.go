@@ -0,0 +1,119 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_LoadGrid(t *testing.T) {
+	tests := []struct {
+		name string
+		from uint64
+		to   uint64
+		fill Fill
+		want []Point
+	}{
+		{
+			name: "Should emit nil records for missing points",
+			from: 10,
+			to:   30,
+			fill: Fill{Method: FillNull},
+			want: []Point{
+				{Timestamp: 10, Record: []string{"1", "a"}},
+				{Timestamp: 15, Record: nil},
+				{Timestamp: 20, Record: nil},
+				{Timestamp: 25, Record: nil},
+				{Timestamp: 30, Record: []string{"3", "c"}},
+			},
+		},
+		{
+			name: "Should interpolate numeric columns linearly",
+			from: 15,
+			to:   35,
+			fill: Fill{Method: FillLinear},
+			want: []Point{
+				{Timestamp: 15, Record: []string{"1.5", "a"}},
+				{Timestamp: 20, Record: []string{"2", "a"}},
+				{Timestamp: 25, Record: []string{"2.5", "a"}},
+				{Timestamp: 30, Record: []string{"3", "c"}},
+				{Timestamp: 35, Record: []string{"3.5", "c"}},
+			},
+		},
+		{
+			name: "Should use the previous point",
+			from: 5,
+			to:   25,
+			fill: Fill{Method: FillPrevious},
+			want: []Point{
+				{Timestamp: 5, Record: nil},
+				{Timestamp: 10, Record: []string{"1", "a"}},
+				{Timestamp: 15, Record: []string{"1", "a"}},
+				{Timestamp: 20, Record: []string{"1", "a"}},
+				{Timestamp: 25, Record: []string{"1", "a"}},
+			},
+		},
+		{
+			name: "Should use the next point",
+			from: 35,
+			to:   55,
+			fill: Fill{Method: FillNext},
+			want: []Point{
+				{Timestamp: 35, Record: []string{"4", "d"}},
+				{Timestamp: 40, Record: []string{"4", "d"}},
+				{Timestamp: 45, Record: nil},
+				{Timestamp: 50, Record: nil},
+				{Timestamp: 55, Record: nil},
+			},
+		},
+		{
+			name: "Should use a constant",
+			from: 25,
+			to:   30,
+			fill: Fill{Method: FillConstant, Value: []string{"0", "-"}},
+			want: []Point{
+				{Timestamp: 25, Record: []string{"0", "-"}},
+				{Timestamp: 30, Record: []string{"3", "c"}},
+			},
+		},
+		{
+			name: "Should emit nil records beyond the max gap",
+			from: 15,
+			to:   35,
+			fill: Fill{Method: FillLinear, MaxGap: 10},
+			want: []Point{
+				{Timestamp: 15, Record: nil},
+				{Timestamp: 20, Record: nil},
+				{Timestamp: 25, Record: nil},
+				{Timestamp: 30, Record: []string{"3", "c"}},
+				{Timestamp: 35, Record: []string{"3.5", "c"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(filestest.TempDir(t), 10)
+			assert.Nil(t, s.Append(10, []string{"1", "a"}))
+			assert.Nil(t, s.Append(30, []string{"3", "c"}))
+			assert.Nil(t, s.Append(40, []string{"4", "d"}))
+
+			var got []Point
+			err := s.LoadGrid(tt.from, tt.to, 5, tt.fill, func(timestamp uint64, record []string) error {
+				got = append(got, Point{Timestamp: timestamp, Record: record})
+				return nil
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStore_LoadGrid_ShouldReturnErrorIfStepIsZero(t *testing.T) {
+	s := NewStore(filestest.TempDir(t), 10)
+
+	err := s.LoadGrid(0, 10, 0, Fill{}, func(uint64, []string) error { return nil })
+
+	assert.Equal(t, ErrInvalidStep, err)
+}
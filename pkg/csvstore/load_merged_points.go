@@ -0,0 +1,87 @@
+package csvstore
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+)
+
+// ErrIncompatibleStores is returned when stores with different time units or
+// signed modes are queried together
+var ErrIncompatibleStores = errors.New("stores with incompatible timestamps")
+
+// LoadMergedPoints loads the points between from and to of all the stores,
+// merging them in timestamp order, and aligning them according to the join
+// mode. The handler receives the timestamp and a record for each store, in the
+// same order of the stores, which is nil for the stores without one. The
+// partitions of each store are read one at a time.
+func LoadMergedPoints(stores []*Store, from uint64, to uint64, join JoinMode, handler func(timestamp uint64, records [][]string) error) error {
+	if len(stores) == 0 {
+		return nil
+	}
+	for _, s := range stores[1:] {
+		if s.index.signed != stores[0].index.signed || s.unit.perSecond() != stores[0].unit.perSecond() {
+			return fmt.Errorf("%w: %s and %s", ErrIncompatibleStores, stores[0].dir, s.dir)
+		}
+	}
+
+	cursors := make(cursorHeap, 0, len(stores))
+	for i, s := range stores {
+		c := newStoreCursor(s, i, from, to)
+		ok, err := c.advance()
+		if err != nil {
+			return err
+		}
+		if ok {
+			cursors = append(cursors, c)
+		}
+	}
+	heap.Init(&cursors)
+
+	last := make([][]string, len(stores))
+	if join == AsOfJoin {
+		// the records in effect at the start of the range
+		for i, s := range stores {
+			_, record, err := s.PointAt(from, 0)
+			if err != nil {
+				return err
+			}
+			last[i] = record
+		}
+	}
+
+	current := make([]*storeCursor, 0, len(stores))
+	for cursors.Len() > 0 {
+		timestamp := cursors[0].point().timestamp
+
+		current = current[:0]
+		current = append(current, heap.Pop(&cursors).(*storeCursor))
+		for join != InterleaveRows && cursors.Len() > 0 && cursors[0].point().timestamp == timestamp {
+			current = append(current, heap.Pop(&cursors).(*storeCursor))
+		}
+
+		records := make([][]string, len(stores))
+		if join == AsOfJoin {
+			copy(records, last)
+		}
+		for _, c := range current {
+			records[c.index] = c.point().record
+			last[c.index] = c.point().record
+
+			ok, err := c.advance()
+			if err != nil {
+				return err
+			}
+			if ok {
+				heap.Push(&cursors, c)
+			}
+		}
+
+		err := handler(timestamp, records)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
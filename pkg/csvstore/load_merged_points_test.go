@@ -0,0 +1,116 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+type mergedRow struct {
+	timestamp uint64
+	records   [][]string
+}
+
+func TestLoadMergedPoints(t *testing.T) {
+	tests := []struct {
+		name string
+		join JoinMode
+		from uint64
+		to   uint64
+		want []mergedRow
+	}{
+		{
+			name: "Should interleave points in timestamp order",
+			join: InterleaveRows,
+			from: 0,
+			to:   29,
+			want: []mergedRow{
+				{1, [][]string{{"bid-1"}, nil}},
+				{1, [][]string{nil, {"ask-1"}}},
+				{5, [][]string{nil, {"ask-5"}}},
+				{12, [][]string{{"bid-12"}, nil}},
+				{25, [][]string{nil, {"ask-25"}}},
+			},
+		},
+		{
+			name: "Should join points with the same timestamp",
+			join: OuterJoin,
+			from: 0,
+			to:   29,
+			want: []mergedRow{
+				{1, [][]string{{"bid-1"}, {"ask-1"}}},
+				{5, [][]string{nil, {"ask-5"}}},
+				{12, [][]string{{"bid-12"}, nil}},
+				{25, [][]string{nil, {"ask-25"}}},
+			},
+		},
+		{
+			name: "Should carry the last known records",
+			join: AsOfJoin,
+			from: 0,
+			to:   29,
+			want: []mergedRow{
+				{1, [][]string{{"bid-1"}, {"ask-1"}}},
+				{5, [][]string{{"bid-1"}, {"ask-5"}}},
+				{12, [][]string{{"bid-12"}, {"ask-5"}}},
+				{25, [][]string{{"bid-12"}, {"ask-25"}}},
+			},
+		},
+		{
+			name: "Should carry the last known records before the range",
+			join: AsOfJoin,
+			from: 2,
+			to:   24,
+			want: []mergedRow{
+				{5, [][]string{{"bid-1"}, {"ask-5"}}},
+				{12, [][]string{{"bid-12"}, {"ask-5"}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bid := NewStore(filestest.TempDir(t), 10)
+			assert.Nil(t, bid.Append(1, []string{"bid-1"}))
+			assert.Nil(t, bid.Append(12, []string{"bid-12"}))
+			ask := NewStore(filestest.TempDir(t), 10)
+			assert.Nil(t, ask.Append(1, []string{"ask-1"}))
+			assert.Nil(t, ask.Append(5, []string{"ask-5"}))
+			assert.Nil(t, ask.Append(25, []string{"ask-25"}))
+
+			var got []mergedRow
+			err := LoadMergedPoints([]*Store{bid, ask}, tt.from, tt.to, tt.join, func(timestamp uint64, records [][]string) error {
+				got = append(got, mergedRow{timestamp, records})
+				return nil
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadMergedPoints_ShouldReturnHandlerError(t *testing.T) {
+	s := newCompactTestStore(t)
+	wantErr := errors.New("some-handler-error")
+
+	err := LoadMergedPoints([]*Store{s}, 0, 39, OuterJoin, func(timestamp uint64, records [][]string) error {
+		return wantErr
+	})
+
+	assert.Equal(t, wantErr, err)
+}
+
+func TestLoadMergedPoints_ShouldReturnErrorIfStoresAreIncompatible(t *testing.T) {
+	stores := []*Store{
+		NewStore(filestest.TempDir(t), 10),
+		NewStore(filestest.TempDir(t), 10, WithSignedTimestamps()),
+	}
+
+	err := LoadMergedPoints(stores, 0, 39, OuterJoin, func(timestamp uint64, records [][]string) error {
+		return nil
+	})
+
+	assert.True(t, errors.Is(err, ErrIncompatibleStores))
+}
@@ -0,0 +1,104 @@
+package csvstore
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_LoadPointsWithReport(t *testing.T) {
+	files := map[string]string{
+		"0_9.csv":   "1,a\nabc,b\n3,c\n",
+		"10_19.csv": "11,d\n12,e,f\n13,g\n",
+	}
+	tests := []struct {
+		name        string
+		opts        []Option
+		want        []Point
+		wantSkipped []*PartitionError
+		wantErr     error
+	}{
+		{
+			name:    "Should abort the read by default",
+			want:    []Point{{Timestamp: 1, Record: []string{"a"}}},
+			wantErr: ErrBadTimestamp,
+		},
+		{
+			name: "Should skip malformed rows",
+			opts: []Option{WithMalformedRows(SkipMalformed)},
+			want: []Point{
+				{Timestamp: 1, Record: []string{"a"}},
+				{Timestamp: 3, Record: []string{"c"}},
+				{Timestamp: 11, Record: []string{"d"}},
+				{Timestamp: 13, Record: []string{"g"}},
+			},
+		},
+		{
+			name: "Should skip and collect malformed rows",
+			opts: []Option{WithMalformedRows(CollectMalformed)},
+			want: []Point{
+				{Timestamp: 1, Record: []string{"a"}},
+				{Timestamp: 3, Record: []string{"c"}},
+				{Timestamp: 11, Record: []string{"d"}},
+				{Timestamp: 13, Record: []string{"g"}},
+			},
+			wantSkipped: []*PartitionError{
+				{Path: "0_9.csv", Line: 2, Value: "abc", Kind: ErrBadTimestamp},
+				{Path: "10_19.csv", Line: 2, Column: -1, Kind: ErrCorruptPartition},
+			},
+		},
+		{
+			name: "Should skip and collect rows not valid for strict reads",
+			opts: []Option{WithMalformedRows(CollectMalformed), WithStrictReads()},
+			want: []Point{
+				{Timestamp: 1, Record: []string{"a"}},
+				{Timestamp: 3, Record: []string{"c"}},
+				{Timestamp: 11, Record: []string{"d"}},
+				{Timestamp: 13, Record: []string{"g"}},
+			},
+			wantSkipped: []*PartitionError{
+				{Path: "0_9.csv", Line: 2, Value: "abc", Kind: ErrBadTimestamp},
+				{Path: "0_9.csv", Line: 4, Value: "25", Kind: ErrOutOfRange},
+				{Path: "10_19.csv", Line: 2, Column: -1, Kind: ErrCorruptPartition},
+				{Path: "10_19.csv", Line: 4, Value: "5", Kind: ErrOutOfRange},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(filestest.TempDir(t), 10, tt.opts...)
+			for name, content := range files {
+				if s.strict {
+					content += map[string]string{"0_9.csv": "25,h\n", "10_19.csv": "5,i\n"}[name]
+				}
+				err := ioutil.WriteFile(s.path(name), []byte(content), 0644)
+				assert.Nil(t, err)
+			}
+
+			var got []Point
+			report, err := s.LoadPointsWithReport(0, 19, func(timestamp uint64, record []string) error {
+				got = append(got, Point{Timestamp: timestamp, Record: record})
+				return nil
+			})
+
+			assert.Equal(t, tt.want, got)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, len(tt.wantSkipped), len(report.Skipped))
+			for i, want := range tt.wantSkipped {
+				skipped := report.Skipped[i]
+				assert.Equal(t, s.path(want.Path), skipped.Path)
+				assert.Equal(t, want.Line, skipped.Line)
+				assert.Equal(t, want.Column, skipped.Column)
+				assert.Equal(t, want.Value, skipped.Value)
+				assert.Equal(t, want.Kind, skipped.Kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package csvstore
+
+type andPredicate []Predicate
+
+type orPredicate []Predicate
+
+type notPredicate struct {
+	predicate Predicate
+}
+
+// And returns the predicate matching the data points that match all the
+// predicates
+func And(predicates ...Predicate) Predicate {
+	return andPredicate(predicates)
+}
+
+// Or returns the predicate matching the data points that match at least one
+// of the predicates
+func Or(predicates ...Predicate) Predicate {
+	return orPredicate(predicates)
+}
+
+// Not returns the predicate matching the data points that don't match the
+// predicate
+func Not(predicate Predicate) Predicate {
+	return &notPredicate{predicate: predicate}
+}
+
+func (p andPredicate) bind(schema Schema) (rowFilter, error) {
+	filters, err := bindAll(p, schema)
+	return andFilter(filters), err
+}
+
+func (p orPredicate) bind(schema Schema) (rowFilter, error) {
+	filters, err := bindAll(p, schema)
+	return orFilter(filters), err
+}
+
+func (p *notPredicate) bind(schema Schema) (rowFilter, error) {
+	filter, err := p.predicate.bind(schema)
+	if err != nil {
+		return nil, err
+	}
+	return &notFilter{filter: filter}, nil
+}
+
+func bindAll(predicates []Predicate, schema Schema) ([]rowFilter, error) {
+	filters := make([]rowFilter, len(predicates))
+	for i, p := range predicates {
+		filter, err := p.bind(schema)
+		if err != nil {
+			return nil, err
+		}
+		filters[i] = filter
+	}
+	return filters, nil
+}
+
+type andFilter []rowFilter
+
+func (f andFilter) match(record []string) bool {
+	for _, filter := range f {
+		if !filter.match(record) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f andFilter) mayMatch(stats []columnStats) bool {
+	for _, filter := range f {
+		if !filter.mayMatch(stats) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f andFilter) columns() []int {
+	return filtersColumns(f)
+}
+
+func (f andFilter) remap(positions map[int]int) rowFilter {
+	return andFilter(remapFilters(f, positions))
+}
+
+type orFilter []rowFilter
+
+func (f orFilter) match(record []string) bool {
+	for _, filter := range f {
+		if filter.match(record) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f orFilter) mayMatch(stats []columnStats) bool {
+	for _, filter := range f {
+		if filter.mayMatch(stats) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f orFilter) columns() []int {
+	return filtersColumns(f)
+}
+
+func (f orFilter) remap(positions map[int]int) rowFilter {
+	return orFilter(remapFilters(f, positions))
+}
+
+type notFilter struct {
+	filter rowFilter
+}
+
+func (f *notFilter) match(record []string) bool {
+	return !f.filter.match(record)
+}
+
+func (f *notFilter) mayMatch(stats []columnStats) bool {
+	// the statistics can't tell if all the rows match the negated filter
+	return true
+}
+
+func (f *notFilter) columns() []int {
+	return f.filter.columns()
+}
+
+func (f *notFilter) remap(positions map[int]int) rowFilter {
+	return &notFilter{filter: f.filter.remap(positions)}
+}
+
+func filtersColumns(filters []rowFilter) []int {
+	var columns []int
+	for _, filter := range filters {
+		columns = append(columns, filter.columns()...)
+	}
+	return columns
+}
+
+func remapFilters(filters []rowFilter, positions map[int]int) []rowFilter {
+	remapped := make([]rowFilter, len(filters))
+	for i, filter := range filters {
+		remapped[i] = filter.remap(positions)
+	}
+	return remapped
+}
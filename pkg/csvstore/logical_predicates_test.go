@@ -0,0 +1,76 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogicalPredicates(t *testing.T) {
+	expensive := Compare("price", Greater, "100")
+	symbol := Compare("symbol", Equal, "X")
+	tests := []struct {
+		name         string
+		predicate    Predicate
+		record       []string
+		want         bool
+		wantMayMatch bool
+		wantColumns  []int
+	}{
+		{
+			name:         "Should match if all predicates match",
+			predicate:    And(expensive, symbol),
+			record:       []string{"X", "1", "101"},
+			want:         true,
+			wantColumns:  []int{2, 0},
+			wantMayMatch: false,
+		},
+		{
+			name:         "Should not match if any predicate doesn't match",
+			predicate:    And(expensive, symbol),
+			record:       []string{"Y", "1", "101"},
+			wantColumns:  []int{2, 0},
+			wantMayMatch: false,
+		},
+		{
+			name:         "Should match if any predicate matches",
+			predicate:    Or(expensive, symbol),
+			record:       []string{"Y", "1", "101"},
+			want:         true,
+			wantColumns:  []int{2, 0},
+			wantMayMatch: true,
+		},
+		{
+			name:         "Should match if predicate doesn't match",
+			predicate:    Not(expensive),
+			record:       []string{"Y", "1", "99"},
+			want:         true,
+			wantColumns:  []int{2},
+			wantMayMatch: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := tt.predicate.bind(predicateTestSchema)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.want, filter.match(tt.record))
+			assert.Equal(t, tt.wantColumns, filter.columns())
+			assert.Equal(t, tt.wantMayMatch, filter.mayMatch([]columnStats{{Other: 1}, {}, {Numeric: 1, Min: 50, Max: 50}}))
+
+			remapped := filter.remap(map[int]int{0: 1, 2: 0})
+			assert.Equal(t, tt.want, remapped.match([]string{tt.record[2], tt.record[0]}))
+		})
+	}
+}
+
+func TestLogicalPredicates_ShouldReturnBindError(t *testing.T) {
+	missing := Compare("missing", Equal, "1")
+
+	for _, p := range []Predicate{And(missing), Or(missing), Not(missing)} {
+		_, err := p.bind(predicateTestSchema)
+
+		assert.True(t, errors.Is(err, ErrUnknownColumn))
+	}
+}
@@ -0,0 +1,17 @@
+package csvstore
+
+// MalformedRows defines how reads handle the rows that can't be parsed, or
+// that are not valid if strict reads are enabled
+type MalformedRows int
+
+const (
+	// AbortOnMalformed aborts the read with a PartitionError
+	AbortOnMalformed MalformedRows = iota
+
+	// SkipMalformed skips the rows silently
+	SkipMalformed
+
+	// CollectMalformed skips the rows and reports them in the ReadReport
+	// returned by LoadPointsWithReport
+	CollectMalformed
+)
@@ -0,0 +1,100 @@
+package csvstore
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// MatchType is the type of comparison done by a Matcher
+type MatchType int
+
+const (
+	// MatchEqual matches labels with the value
+	MatchEqual MatchType = iota
+	// MatchNotEqual matches labels without the value
+	MatchNotEqual
+	// MatchRegexp matches labels whose value fully matches the regular
+	// expression
+	MatchRegexp
+	// MatchNotRegexp matches labels whose value doesn't fully match the
+	// regular expression
+	MatchNotRegexp
+)
+
+// String returns the operator of the match type, e.g. "=~"
+func (t MatchType) String() string {
+	switch t {
+	case MatchEqual:
+		return "="
+	case MatchNotEqual:
+		return "!="
+	case MatchRegexp:
+		return "=~"
+	case MatchNotRegexp:
+		return "!~"
+	default:
+		return fmt.Sprintf("MatchType(%d)", int(t))
+	}
+}
+
+// Matcher selects the series whose label matches a value; a missing label
+// has the empty value. Matchers should be created with NewMatcher, otherwise
+// an invalid regular expression matches nothing. The fields must not be
+// changed after the first match.
+type Matcher struct {
+	Type  MatchType
+	Name  string
+	Value string
+
+	// once compiles the regular expression at the first use
+	once  sync.Once
+	re    *regexp.Regexp
+	reErr error
+}
+
+// NewMatcher creates a matcher for the label, returning an error if the
+// value is not a valid regular expression for a regexp matcher
+func NewMatcher(t MatchType, name string, value string) (*Matcher, error) {
+	m := &Matcher{Type: t, Name: name, Value: value}
+	if t == MatchRegexp || t == MatchNotRegexp {
+		_, err := m.regexp()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// regexp returns the compiled regular expression of the value, anchored to
+// match the whole label value
+func (m *Matcher) regexp() (*regexp.Regexp, error) {
+	m.once.Do(func() {
+		m.re, m.reErr = regexp.Compile("^(?:" + m.Value + ")$")
+	})
+	return m.re, m.reErr
+}
+
+// Matches returns true if the label value matches
+func (m *Matcher) Matches(value string) bool {
+	switch m.Type {
+	case MatchEqual:
+		return value == m.Value
+	case MatchNotEqual:
+		return value != m.Value
+	case MatchRegexp, MatchNotRegexp:
+		re, err := m.regexp()
+		if err != nil {
+			return false
+		}
+		return re.MatchString(value) == (m.Type == MatchRegexp)
+	default:
+		return false
+	}
+}
+
+// String returns the matcher as name, operator and quoted value, e.g.
+// host=~"a.*"
+func (m *Matcher) String() string {
+	return fmt.Sprintf("%s%v%q", m.Name, m.Type, m.Value)
+}
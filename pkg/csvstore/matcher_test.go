@@ -0,0 +1,98 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher_Matches(t *testing.T) {
+	tests := []struct {
+		name       string
+		matchType  MatchType
+		value      string
+		label      string
+		want       bool
+		wantString string
+	}{
+		{
+			name:       "Should match equal value",
+			matchType:  MatchEqual,
+			value:      "a",
+			label:      "a",
+			want:       true,
+			wantString: `host="a"`,
+		},
+		{
+			name:       "Should not match different value",
+			matchType:  MatchEqual,
+			value:      "a",
+			label:      "b",
+			wantString: `host="a"`,
+		},
+		{
+			name:       "Should match different value with negation",
+			matchType:  MatchNotEqual,
+			value:      "a",
+			label:      "b",
+			want:       true,
+			wantString: `host!="a"`,
+		},
+		{
+			name:       "Should match regular expression",
+			matchType:  MatchRegexp,
+			value:      "a|b",
+			label:      "b",
+			want:       true,
+			wantString: `host=~"a|b"`,
+		},
+		{
+			name:       "Should not match partially the regular expression",
+			matchType:  MatchRegexp,
+			value:      "a",
+			label:      "ab",
+			wantString: `host=~"a"`,
+		},
+		{
+			name:       "Should match missing label with negated regular expression",
+			matchType:  MatchNotRegexp,
+			value:      ".+",
+			want:       true,
+			wantString: `host!~".+"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMatcher(tt.matchType, "host", tt.value)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.want, m.Matches(tt.label))
+			assert.Equal(t, tt.wantString, m.String())
+			literal := &Matcher{Type: tt.matchType, Name: "host", Value: tt.value}
+			assert.Equal(t, tt.want, literal.Matches(tt.label))
+		})
+	}
+}
+
+func TestMatcher_Matches_ShouldNotMatchInvalidRegexpOfLiteral(t *testing.T) {
+	m := &Matcher{Type: MatchNotRegexp, Name: "host", Value: "("}
+
+	assert.False(t, m.Matches("a"))
+}
+
+func TestMatcher_Matches_ShouldCompileRegexpOfLiteralOnce(t *testing.T) {
+	m := &Matcher{Type: MatchRegexp, Name: "host", Value: "a.*"}
+
+	assert.True(t, m.Matches("ab"))
+	re := m.re
+	assert.NotNil(t, re)
+	assert.False(t, m.Matches("b"))
+	assert.Same(t, re, m.re)
+}
+
+func TestNewMatcher_ShouldReturnErrorIfRegexpIsInvalid(t *testing.T) {
+	got, err := NewMatcher(MatchRegexp, "host", "(")
+
+	assert.NotNil(t, err)
+	assert.Nil(t, got)
+}
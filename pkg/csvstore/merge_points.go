@@ -0,0 +1,49 @@
+package csvstore
+
+// mergePoints merges the incoming points into the existing ones, both sorted
+// by timestamp, using the policy to resolve the points with the same
+// timestamp
+func mergePoints(existing dataPointList, incoming dataPointList, policy ConflictPolicy) (dataPointList, InsertStats, error) {
+	if policy == nil {
+		policy = ReplaceOnConflict
+	}
+
+	var stats InsertStats
+	merged := make(dataPointList, 0, len(existing)+len(incoming))
+
+	i, j := 0, 0
+	for i < len(existing) && j < len(incoming) {
+		switch {
+		case existing[i].timestamp < incoming[j].timestamp:
+			merged = append(merged, existing[i])
+			i++
+
+		case existing[i].timestamp > incoming[j].timestamp:
+			merged = append(merged, incoming[j])
+			stats.Inserted++
+			j++
+
+		default:
+			resolved, err := policy(existing[i].timestamp, existing[i].record, incoming[j].record)
+			if err != nil {
+				return nil, InsertStats{}, err
+			}
+			if resolved == nil {
+				merged = append(merged, existing[i])
+				stats.Skipped++
+			} else {
+				merged = append(merged, &dataPoint{timestamp: existing[i].timestamp, record: resolved})
+				stats.Replaced++
+			}
+			i++
+			j++
+		}
+	}
+	merged = append(merged, existing[i:]...)
+	for ; j < len(incoming); j++ {
+		merged = append(merged, incoming[j])
+		stats.Inserted++
+	}
+
+	return merged, stats, nil
+}
@@ -0,0 +1,102 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergePoints(t *testing.T) {
+	type args struct {
+		existing dataPointList
+		incoming dataPointList
+		policy   ConflictPolicy
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      dataPointList
+		wantStats InsertStats
+		wantErr   error
+	}{
+		{
+			name: "Should return incoming points if there is no existing one",
+			args: args{
+				existing: dataPointList{},
+				incoming: dataPointList{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+			},
+			want: dataPointList{
+				{timestamp: 1, record: []string{"some-value-at-1"}},
+			},
+			wantStats: InsertStats{Inserted: 1},
+		},
+		{
+			name: "Should interleave points and replace existing ones by default",
+			args: args{
+				existing: dataPointList{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+					{timestamp: 3, record: []string{"some-value-at-3"}},
+					{timestamp: 5, record: []string{"some-value-at-5"}},
+				},
+				incoming: dataPointList{
+					{timestamp: 0, record: []string{"some-value-at-0"}},
+					{timestamp: 3, record: []string{"some-other-value-at-3"}},
+					{timestamp: 4, record: []string{"some-value-at-4"}},
+					{timestamp: 6, record: []string{"some-value-at-6"}},
+				},
+			},
+			want: dataPointList{
+				{timestamp: 0, record: []string{"some-value-at-0"}},
+				{timestamp: 1, record: []string{"some-value-at-1"}},
+				{timestamp: 3, record: []string{"some-other-value-at-3"}},
+				{timestamp: 4, record: []string{"some-value-at-4"}},
+				{timestamp: 5, record: []string{"some-value-at-5"}},
+				{timestamp: 6, record: []string{"some-value-at-6"}},
+			},
+			wantStats: InsertStats{Inserted: 3, Replaced: 1},
+		},
+		{
+			name: "Should keep existing points",
+			args: args{
+				existing: dataPointList{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+				incoming: dataPointList{
+					{timestamp: 1, record: []string{"some-other-value-at-1"}},
+				},
+				policy: KeepOnConflict,
+			},
+			want: dataPointList{
+				{timestamp: 1, record: []string{"some-value-at-1"}},
+			},
+			wantStats: InsertStats{Skipped: 1},
+		},
+		{
+			name: "Should return error if the policy raises it",
+			args: args{
+				existing: dataPointList{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+				incoming: dataPointList{
+					{timestamp: 1, record: []string{"some-other-value-at-1"}},
+				},
+				policy: func(timestamp uint64, existing []string, incoming []string) ([]string, error) {
+					return nil, errors.New("some-policy-error")
+				},
+			},
+			wantErr: errors.New("some-policy-error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, stats, err := mergePoints(tt.args.existing, tt.args.incoming, tt.args.policy)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantStats, stats)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
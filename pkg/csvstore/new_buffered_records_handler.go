@@ -0,0 +1,37 @@
+package csvstore
+
+// newBufferedRecordsHandler merges the buffered points into the records
+// passed to the handler, sorted by timestamp. The returned drain function
+// passes the buffered points after the last record read.
+func newBufferedRecordsHandler(buffered dataPointList, policy ConflictPolicy, handler func(uint64, []string) error) (func(uint64, []string) error, func() error) {
+	next := 0
+
+	recordsHandler := func(timestamp uint64, record []string) error {
+		for ; next < len(buffered) && buffered[next].timestamp <= timestamp; next++ {
+			point := buffered[next]
+			if point.timestamp == timestamp {
+				record = resolveConflict(policy, timestamp, record, point.record)
+				continue
+			}
+
+			err := handler(point.timestamp, point.record)
+			if err != nil {
+				return err
+			}
+		}
+
+		return handler(timestamp, record)
+	}
+
+	drain := func() error {
+		for ; next < len(buffered); next++ {
+			err := handler(buffered[next].timestamp, buffered[next].record)
+			if err != nil {
+				return errOrNilIfEOF(err)
+			}
+		}
+		return nil
+	}
+
+	return recordsHandler, drain
+}
@@ -0,0 +1,102 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newBufferedRecordsHandler(t *testing.T) {
+	type args struct {
+		buffered dataPointList
+		policy   ConflictPolicy
+		records  []*dataPoint
+	}
+	tests := []struct {
+		name       string
+		args       args
+		handlerErr error
+		want       []*dataPoint
+		wantErr    error
+	}{
+		{
+			name: "Should merge buffered points with records",
+			args: args{
+				buffered: dataPointList{
+					{timestamp: 0, record: []string{"some-buffered-value-at-0"}},
+					{timestamp: 2, record: []string{"some-buffered-value-at-2"}},
+					{timestamp: 5, record: []string{"some-buffered-value-at-5"}},
+				},
+				records: []*dataPoint{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+					{timestamp: 2, record: []string{"some-value-at-2"}},
+					{timestamp: 3, record: []string{"some-value-at-3"}},
+				},
+			},
+			want: []*dataPoint{
+				{timestamp: 0, record: []string{"some-buffered-value-at-0"}},
+				{timestamp: 1, record: []string{"some-value-at-1"}},
+				{timestamp: 2, record: []string{"some-buffered-value-at-2"}},
+				{timestamp: 3, record: []string{"some-value-at-3"}},
+				{timestamp: 5, record: []string{"some-buffered-value-at-5"}},
+			},
+		},
+		{
+			name: "Should use policy to resolve conflicts",
+			args: args{
+				buffered: dataPointList{
+					{timestamp: 2, record: []string{"some-buffered-value-at-2"}},
+				},
+				policy: KeepOnConflict,
+				records: []*dataPoint{
+					{timestamp: 2, record: []string{"some-value-at-2"}},
+				},
+			},
+			want: []*dataPoint{
+				{timestamp: 2, record: []string{"some-value-at-2"}},
+			},
+		},
+		{
+			name: "Should return error if handler raises it",
+			args: args{
+				buffered: dataPointList{
+					{timestamp: 0, record: []string{"some-buffered-value-at-0"}},
+				},
+				records: []*dataPoint{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+			},
+			handlerErr: errors.New("some-handler-error"),
+			want: []*dataPoint{
+				{timestamp: 0, record: []string{"some-buffered-value-at-0"}},
+			},
+			wantErr: errors.New("some-handler-error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*dataPoint
+			handler := func(timestamp uint64, record []string) error {
+				got = append(got, &dataPoint{timestamp: timestamp, record: record})
+				return tt.handlerErr
+			}
+
+			recordsHandler, drain := newBufferedRecordsHandler(tt.args.buffered, tt.args.policy, handler)
+
+			var err error
+			for _, r := range tt.args.records {
+				err = recordsHandler(r.timestamp, r.record)
+				if err != nil {
+					break
+				}
+			}
+			if err == nil {
+				err = drain()
+			}
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
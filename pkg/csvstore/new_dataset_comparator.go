@@ -3,6 +3,12 @@ package csvstore
 func newDatasetComparator(target uint64) func(interface{}) int {
 	return func(value interface{}) int {
 		d := value.(*dataPoint)
-		return int(d.timestamp - target)
+		switch {
+		case d.timestamp < target:
+			return -1
+		case d.timestamp > target:
+			return 1
+		}
+		return 0
 	}
 }
@@ -24,7 +24,7 @@ func Test_newDatasetComparator(t *testing.T) {
 					timestamp: 12,
 				},
 			},
-			want: 2,
+			want: 1,
 		},
 		{
 			name: "Should return 0 if target is equal to value",
@@ -44,7 +44,17 @@ func Test_newDatasetComparator(t *testing.T) {
 					timestamp: 20,
 				},
 			},
-			want: -10,
+			want: -1,
+		},
+		{
+			name: "Should return a value less then 0 if the difference overflows",
+			args: args{
+				target: signBit + 10,
+				value: &dataPoint{
+					timestamp: 5,
+				},
+			},
+			want: -1,
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,12 @@
+package csvstore
+
+// newPredicateRecordsHandler passes to the handler only the records matching
+// the filter
+func newPredicateRecordsHandler(filter rowFilter, handler func(uint64, []string) error) func(uint64, []string) error {
+	return func(timestamp uint64, record []string) error {
+		if filter.match(record) {
+			return handler(timestamp, record)
+		}
+		return nil
+	}
+}
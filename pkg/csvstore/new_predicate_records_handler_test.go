@@ -0,0 +1,22 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newPredicateRecordsHandler(t *testing.T) {
+	filter, err := Compare("0", Equal, "a").bind(nil)
+	assert.Nil(t, err)
+	var got []uint64
+	handler := newPredicateRecordsHandler(filter, func(timestamp uint64, record []string) error {
+		got = append(got, timestamp)
+		return nil
+	})
+
+	assert.Nil(t, handler(1, []string{"a"}))
+	assert.Nil(t, handler(2, []string{"b"}))
+
+	assert.Equal(t, []uint64{1}, got)
+}
@@ -0,0 +1,25 @@
+package csvstore
+
+// newProjectionHandler passes to the handler only the specified columns of
+// the records, or the whole records if columns is nil
+func newProjectionHandler(columns []int, handler func(uint64, []string) error) func(uint64, []string) error {
+	if columns == nil {
+		return handler
+	}
+
+	return func(timestamp uint64, record []string) error {
+		return handler(timestamp, project(record, columns))
+	}
+}
+
+// project returns a new record with the specified columns of the record, empty
+// if missing
+func project(record []string, columns []int) []string {
+	projected := make([]string, len(columns))
+	for i, column := range columns {
+		if column < len(record) {
+			projected[i] = record[column]
+		}
+	}
+	return projected
+}
@@ -0,0 +1,43 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newProjectionHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []int
+		record  []string
+		want    []string
+	}{
+		{
+			name:   "Should pass the whole record if columns are nil",
+			record: []string{"a", "b"},
+			want:   []string{"a", "b"},
+		},
+		{
+			name:    "Should pass the selected columns",
+			columns: []int{1, 3, 0},
+			record:  []string{"a", "b"},
+			want:    []string{"b", "", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			handler := newProjectionHandler(tt.columns, func(timestamp uint64, record []string) error {
+				assert.Equal(t, uint64(7), timestamp)
+				got = record
+				return nil
+			})
+
+			err := handler(7, tt.record)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package csvstore
+
+func newStrictRecordsHandler(from uint64, to uint64, codec timestampCodec, handler func(uint64, []string) error) func(uint64, []string) error {
+	rows := 0
+	var previous uint64
+	return func(timestamp uint64, record []string) error {
+		if timestamp < from || timestamp > to {
+			return &PartitionError{Column: codec.column, Value: codec.formatValue(timestamp), Kind: ErrOutOfRange}
+		}
+		if rows > 0 && timestamp <= previous {
+			return &PartitionError{Column: codec.column, Value: codec.formatValue(timestamp), Kind: ErrOutOfOrder}
+		}
+		previous = timestamp
+		rows++
+
+		return handler(timestamp, record)
+	}
+}
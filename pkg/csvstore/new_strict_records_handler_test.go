@@ -0,0 +1,75 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newStrictRecordsHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		timestamps []uint64
+		handlerErr error
+		wantCalls  int
+		wantErr    error
+	}{
+		{
+			name:       "Should call next for valid rows",
+			timestamps: []uint64{10, 11, 19},
+			wantCalls:  3,
+		},
+		{
+			name:       "Should return error if timestamp is less than from",
+			timestamps: []uint64{10, 9},
+			wantCalls:  1,
+			wantErr:    &PartitionError{Value: "9", Kind: ErrOutOfRange},
+		},
+		{
+			name:       "Should return error if timestamp is greater than to",
+			timestamps: []uint64{20},
+			wantErr:    &PartitionError{Value: "20", Kind: ErrOutOfRange},
+		},
+		{
+			name:       "Should return error if timestamp is less than the previous one",
+			timestamps: []uint64{12, 11},
+			wantCalls:  1,
+			wantErr:    &PartitionError{Value: "11", Kind: ErrOutOfOrder},
+		},
+		{
+			name:       "Should return error if timestamp is equal to the previous one",
+			timestamps: []uint64{12, 12},
+			wantCalls:  1,
+			wantErr:    &PartitionError{Value: "12", Kind: ErrOutOfOrder},
+		},
+		{
+			name:       "Should return error if handler raises it",
+			timestamps: []uint64{12},
+			handlerErr: errors.New("some-handler-error"),
+			wantCalls:  1,
+			wantErr:    errors.New("some-handler-error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			handler := newStrictRecordsHandler(10, 19, timestampCodec{}, func(timestamp uint64, record []string) error {
+				calls++
+				assert.Equal(t, []string{"some-value"}, record)
+				return tt.handlerErr
+			})
+
+			var err error
+			for _, timestamp := range tt.timestamps {
+				err = handler(timestamp, []string{"some-value"})
+				if err != nil {
+					break
+				}
+			}
+
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -1,16 +1,12 @@
 package csvstore
 
-import (
-	"strconv"
-)
-
-func newTimestampHandler(handler func(uint64, []string) error) func([]string) error {
+func newTimestampHandler(codec timestampCodec, handler func(uint64, []string) error) func([]string) error {
 	return func(record []string) error {
-		timestamp, err := strconv.ParseUint(record[0], 10, 64)
+		timestamp, rest, err := codec.parse(record)
 		if err != nil {
 			return err
 		}
 
-		return handler(timestamp, record[1:])
+		return handler(timestamp, rest)
 	}
 }
@@ -33,7 +33,7 @@ func Test_newTimestampHandler(t *testing.T) {
 				record: []string{"invalid-value", "some-other-column-2"},
 			},
 			shouldCallNext: false,
-			wantErr:        errors.New("strconv.ParseUint: parsing \"invalid-value\": invalid syntax"),
+			wantErr:        errors.New(":0: invalid timestamp \"invalid-value\" in column 0: strconv.ParseUint: parsing \"invalid-value\": invalid syntax"),
 		},
 		{
 			name: "Should return error if next handler raises it",
@@ -56,7 +56,7 @@ func Test_newTimestampHandler(t *testing.T) {
 				return tt.handler.err
 			}
 
-			err := newTimestampHandler(handler)(tt.handler.record)
+			err := newTimestampHandler(timestampCodec{}, handler)(tt.handler.record)
 
 			assert.Equal(t, tt.shouldCallNext, called)
 			if tt.wantErr != nil {
@@ -0,0 +1,12 @@
+package csvstore
+
+// newTrimRecordsHandler passes to the handler only the first columns of the
+// records
+func newTrimRecordsHandler(columns int, handler func(uint64, []string) error) func(uint64, []string) error {
+	return func(timestamp uint64, record []string) error {
+		if len(record) > columns {
+			record = record[:columns:columns]
+		}
+		return handler(timestamp, record)
+	}
+}
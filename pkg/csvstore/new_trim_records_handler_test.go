@@ -0,0 +1,20 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newTrimRecordsHandler(t *testing.T) {
+	var got [][]string
+	handler := newTrimRecordsHandler(2, func(timestamp uint64, record []string) error {
+		got = append(got, record)
+		return nil
+	})
+
+	assert.Nil(t, handler(1, []string{"a", "b", "c"}))
+	assert.Nil(t, handler(2, []string{"a"}))
+
+	assert.Equal(t, [][]string{{"a", "b"}, {"a"}}, got)
+}
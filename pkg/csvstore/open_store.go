@@ -0,0 +1,54 @@
+package csvstore
+
+import (
+	"fmt"
+	"os"
+)
+
+// OpenStore is like NewStore, but it persists the time unit and the signed
+// mode of the store in its folder, creating it if needed. If they have already
+// been persisted, the unit is used when not set by the options, while the
+// signed mode must always be set as persisted, as it changes the meaning of
+// the timestamps; an error wrapping ErrConfigMismatch is returned if the
+// options set different ones, and one
+// wrapping ErrInvalidTimestampFormat or ErrInvalidDialect if the format of
+// the partition files is not valid.
+func OpenStore(dir string, interval uint64, opts ...Option) (*Store, error) {
+	s := newStore(dir, interval, opts...)
+	config, err := readStoreConfig(dir)
+	switch {
+	case os.IsNotExist(err):
+		config = nil
+		if s.unit == 0 {
+			s.unit = Second
+		}
+
+	case err != nil:
+		return nil, err
+
+	default:
+		if s.unit != 0 && s.unit != config.Unit {
+			return nil, fmt.Errorf("%w: unit %v, persisted %v", ErrConfigMismatch, s.unit, config.Unit)
+		}
+		if s.index.signed != config.Signed {
+			return nil, fmt.Errorf("%w: signed %v, persisted %v", ErrConfigMismatch, s.index.signed, config.Signed)
+		}
+		s.unit = config.Unit
+	}
+
+	// the precision of the timestamp layout depends on the persisted unit
+	err = s.validateFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		err = writeStoreConfig(dir, &storeConfig{Unit: s.unit, Signed: s.index.signed})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.start()
+	return s, nil
+}
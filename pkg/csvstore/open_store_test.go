@@ -0,0 +1,111 @@
+package csvstore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenStore(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     *storeConfig
+		opts       []Option
+		wantUnit   TimeUnit
+		wantSigned bool
+		wantErr    error
+	}{
+		{
+			name:     "Should persist the default configuration",
+			wantUnit: Second,
+		},
+		{
+			name:       "Should persist the configuration of the options",
+			opts:       []Option{WithTimeUnit(Millisecond), WithSignedTimestamps()},
+			wantUnit:   Millisecond,
+			wantSigned: true,
+		},
+		{
+			name:     "Should use the persisted unit",
+			config:   &storeConfig{Unit: Nanosecond},
+			wantUnit: Nanosecond,
+		},
+		{
+			name:       "Should accept options equal to the persisted configuration",
+			config:     &storeConfig{Unit: Nanosecond, Signed: true},
+			opts:       []Option{WithTimeUnit(Nanosecond), WithSignedTimestamps()},
+			wantUnit:   Nanosecond,
+			wantSigned: true,
+		},
+		{
+			name:    "Should return error if the unit is different",
+			config:  &storeConfig{Unit: Nanosecond},
+			opts:    []Option{WithTimeUnit(Millisecond)},
+			wantErr: ErrConfigMismatch,
+		},
+		{
+			name:    "Should return error if the signed mode is different",
+			config:  &storeConfig{Unit: Second},
+			opts:    []Option{WithSignedTimestamps()},
+			wantErr: ErrConfigMismatch,
+		},
+		{
+			name:    "Should return error if the persisted signed mode is not set",
+			config:  &storeConfig{Unit: Second, Signed: true},
+			wantErr: ErrConfigMismatch,
+		},
+		{
+			name:    "Should return error if the timestamp column is negative",
+			opts:    []Option{WithTimestampFormat(TimestampFormat{Column: -1})},
+			wantErr: ErrInvalidTimestampFormat,
+		},
+		{
+			name:    "Should return error if comments are set with the timestamps not in the first column",
+			opts:    []Option{WithDialect(Dialect{Comment: '#'}), WithTimestampFormat(TimestampFormat{Column: 1})},
+			wantErr: ErrInvalidDialect,
+		},
+		{
+			name:    "Should return error if the timestamp layout can't keep the precision of the unit",
+			opts:    []Option{WithTimeUnit(Millisecond), WithTimestampFormat(TimestampFormat{Layout: time.RFC3339})},
+			wantErr: ErrInvalidTimestampFormat,
+		},
+		{
+			name:    "Should return error if the timestamp layout can't keep the precision of the persisted unit",
+			config:  &storeConfig{Unit: Millisecond},
+			opts:    []Option{WithTimestampFormat(TimestampFormat{Layout: time.RFC3339})},
+			wantErr: ErrInvalidTimestampFormat,
+		},
+		{
+			name:     "Should accept a timestamp layout that keeps the precision of the unit",
+			opts:     []Option{WithTimeUnit(Millisecond), WithTimestampFormat(TimestampFormat{Layout: time.RFC3339Nano})},
+			wantUnit: Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(filestest.TempDir(t), "some-store")
+			if tt.config != nil {
+				err := writeStoreConfig(dir, tt.config)
+				assert.Nil(t, err)
+			}
+
+			got, err := OpenStore(dir, 10, tt.opts...)
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantUnit, got.unit)
+			assert.Equal(t, tt.wantSigned, got.index.signed)
+			config, err := readStoreConfig(dir)
+			assert.Nil(t, err)
+			assert.Equal(t, &storeConfig{Unit: tt.wantUnit, Signed: tt.wantSigned}, config)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+package csvstore
+
+// Option configures a Store
+type Option func(*Store)
@@ -7,22 +7,34 @@ import (
 	"strings"
 )
 
-func parseDatasetName(name string) (from uint64, to uint64, err error) {
-	fileTimestamps := strings.Split(strings.TrimSuffix(name, filepath.Ext(name)), "_")
+func parseDatasetName(name string, signed bool) (from uint64, to uint64, err error) {
+	base := strings.TrimSuffix(name, compressionOf(name).suffix())
+	fileTimestamps := strings.Split(strings.TrimSuffix(base, filepath.Ext(base)), "_")
 
 	if len(fileTimestamps) != 2 {
 		return 0, 0, errors.New("Wrong file name format: " + name)
 	}
 
-	from, err = strconv.ParseUint(fileTimestamps[0], 0, 64)
+	from, err = parseDatasetTimestamp(fileTimestamps[0], signed)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	to, err = strconv.ParseUint(fileTimestamps[1], 0, 64)
+	to, err = parseDatasetTimestamp(fileTimestamps[1], signed)
 	if err != nil {
 		return 0, 0, err
 	}
 
 	return from, to, nil
 }
+
+func parseDatasetTimestamp(value string, signed bool) (uint64, error) {
+	if signed {
+		timestamp, err := strconv.ParseInt(value, 0, 64)
+		if err != nil {
+			return 0, err
+		}
+		return FromSigned(timestamp), nil
+	}
+	return strconv.ParseUint(value, 0, 64)
+}
@@ -7,7 +7,8 @@ import (
 
 func Test_parseDatasetName(t *testing.T) {
 	type args struct {
-		name string
+		name   string
+		signed bool
 	}
 	tests := []struct {
 		name     string
@@ -25,6 +26,43 @@ func Test_parseDatasetName(t *testing.T) {
 			wantTo:   5678,
 			wantErr:  nil,
 		},
+		{
+			name: "Gzip",
+			args: args{
+				name: "1234_5678.csv.gz",
+			},
+			wantFrom: 1234,
+			wantTo:   5678,
+			wantErr:  nil,
+		},
+		{
+			name: "Zstd",
+			args: args{
+				name: "1234_5678.csv.zst",
+			},
+			wantFrom: 1234,
+			wantTo:   5678,
+			wantErr:  nil,
+		},
+		{
+			name: "Signed",
+			args: args{
+				name:   "-10_-1.csv",
+				signed: true,
+			},
+			wantFrom: FromSigned(-10),
+			wantTo:   FromSigned(-1),
+			wantErr:  nil,
+		},
+		{
+			name: "InvalidSigned",
+			args: args{
+				name: "-10_-1.csv",
+			},
+			wantFrom: 0,
+			wantTo:   0,
+			wantErr:  errors.New("strconv.ParseUint: parsing \"-10\": invalid syntax"),
+		},
 		{
 			name: "InvalidFormat",
 			args: args{
@@ -55,7 +93,7 @@ func Test_parseDatasetName(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotFrom, gotTo, err := parseDatasetName(tt.args.name)
+			gotFrom, gotTo, err := parseDatasetName(tt.args.name, tt.args.signed)
 			if err != nil && err.Error() != tt.wantErr.Error() {
 				t.Errorf("parseDatasetName() error = %v, wantErr %v", err, tt.wantErr)
 				return
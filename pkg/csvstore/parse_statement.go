@@ -0,0 +1,82 @@
+package csvstore
+
+import "strconv"
+
+// ParseStatement parses a statement of the query language:
+//
+//	SELECT * | column, ... | aggregation(column), ...
+//	FROM series
+//	[WHERE condition]
+//	[GROUP BY time(duration)]
+//	[LIMIT count]
+//
+// The condition combines with AND, OR, NOT and parentheses the comparisons
+// (=, !=, <>, <, <=, >, >=) of the columns with strings or numbers; the time
+// can be compared with an integer timestamp or an RFC 3339 string, only in
+// conditions combined with AND at the top level. Keywords are case
+// insensitive, and the errors are of type *SyntaxError.
+func ParseStatement(query string) (*Statement, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &qlParser{tokens: tokens}
+	stmt := &Statement{}
+
+	err = p.expectKeyword("SELECT")
+	if err != nil {
+		return nil, err
+	}
+	err = p.parseFields(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.expectKeyword("FROM")
+	if err != nil {
+		return nil, err
+	}
+	series := p.peek()
+	if series.kind != qlIdent && series.kind != qlString {
+		return nil, p.unexpected("series")
+	}
+	p.advance()
+	stmt.Series = series.text
+
+	if p.accept("WHERE") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Where, stmt.bounds, err = compileWhere(expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if group := p.peek(); p.accept("GROUP") {
+		if len(stmt.Aggregates) == 0 {
+			return nil, &SyntaxError{Pos: group.pos, Msg: "GROUP BY requires aggregations"}
+		}
+		err = p.parseGroupBy(stmt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.accept("LIMIT") {
+		limit, err := p.expect(qlNumber, "limit")
+		if err != nil {
+			return nil, err
+		}
+		stmt.Limit, err = strconv.Atoi(limit.text)
+		if err != nil || stmt.Limit <= 0 {
+			return nil, &SyntaxError{Pos: limit.pos, Msg: "invalid limit " + limit.String()}
+		}
+	}
+
+	if p.peek().kind != qlEOF {
+		return nil, p.unexpected("end of statement")
+	}
+	return stmt, nil
+}
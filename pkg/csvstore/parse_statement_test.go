@@ -0,0 +1,153 @@
+package csvstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatement(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  *Statement
+	}{
+		{
+			name:  "Should parse a selection of all the columns",
+			query: "select * from trades",
+			want:  &Statement{Series: "trades"},
+		},
+		{
+			name:  "Should parse columns, a condition and a limit",
+			query: "SELECT symbol, price FROM 'temp/room 1' WHERE symbol = 'X' OR NOT (price > 10 AND 1 <= 5) LIMIT 10",
+			want: &Statement{
+				Series:  "temp/room 1",
+				Columns: []string{"symbol", "price"},
+				Where: Or(
+					Compare("symbol", Equal, "X"),
+					Not(And(Compare("price", Greater, "10"), Compare("1", LessOrEqual, "5"))),
+				),
+				Limit: 10,
+			},
+		},
+		{
+			name:  "Should extract the time bounds and the step",
+			query: "SELECT mean(price), count(*) FROM trades WHERE time >= '2021-01-01T00:00:00Z' AND symbol != 'X' AND time < 100 GROUP BY time(1h)",
+			want: &Statement{
+				Series:     "trades",
+				Aggregates: []Aggregate{{Func: Mean, Column: "price"}, {Func: Count, Column: AllRows}},
+				Where:      Compare("symbol", NotEqual, "X"),
+				bounds: []*timeBound{
+					{op: GreaterOrEqual, time: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), isTime: true},
+					{op: Less, timestamp: 100},
+				},
+				step: time.Hour,
+			},
+		},
+		{
+			name:  "Should parse a step in the unit of the store",
+			query: "SELECT max(price) FROM trades GROUP BY time(60)",
+			want: &Statement{
+				Series:     "trades",
+				Aggregates: []Aggregate{{Func: Max, Column: "price"}},
+				stepUnits:  60,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatement(tt.query)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseStatement_ShouldReturnSyntaxError(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  *SyntaxError
+	}{
+		{
+			name:  "Should return error if SELECT is missing",
+			query: "FROM trades",
+			want:  &SyntaxError{Pos: 1, Msg: `expected SELECT, found "FROM"`},
+		},
+		{
+			name:  "Should return error if FROM is missing",
+			query: "SELECT price WHERE price > 1",
+			want:  &SyntaxError{Pos: 14, Msg: `expected FROM, found "WHERE"`},
+		},
+		{
+			name:  "Should return error if the series is missing",
+			query: "SELECT *  FROM",
+			want:  &SyntaxError{Pos: 15, Msg: "expected series, found end of statement"},
+		},
+		{
+			name:  "Should return error for an unknown aggregation",
+			query: "SELECT median(price) FROM trades",
+			want:  &SyntaxError{Pos: 8, Msg: `unknown aggregation "median"`},
+		},
+		{
+			name:  "Should return error if columns are selected with aggregations",
+			query: "SELECT max(price), symbol FROM trades",
+			want:  &SyntaxError{Pos: 20, Msg: "columns cannot be selected with aggregations"},
+		},
+		{
+			name:  "Should return error if a comparison has no value",
+			query: "SELECT * FROM trades WHERE price >",
+			want:  &SyntaxError{Pos: 35, Msg: "expected string or number, found end of statement"},
+		},
+		{
+			name:  "Should return error if a parenthesis is not closed",
+			query: "SELECT * FROM trades WHERE (price > 1",
+			want:  &SyntaxError{Pos: 38, Msg: `expected ")", found end of statement`},
+		},
+		{
+			name:  "Should return error if the time is in a disjunction",
+			query: "SELECT * FROM trades WHERE time > 1 OR price > 1",
+			want:  &SyntaxError{Pos: 28, Msg: "time conditions can only be combined with AND"},
+		},
+		{
+			name:  "Should return error for an invalid time",
+			query: "SELECT * FROM trades WHERE time > 'yesterday'",
+			want:  &SyntaxError{Pos: 35, Msg: `invalid time string "yesterday", expected an integer timestamp or an RFC 3339 string`},
+		},
+		{
+			name:  "Should return error if grouping without aggregations",
+			query: "SELECT * FROM trades GROUP BY time(1h)",
+			want:  &SyntaxError{Pos: 22, Msg: "GROUP BY requires aggregations"},
+		},
+		{
+			name:  "Should return error for an invalid duration",
+			query: "SELECT count(*) FROM trades GROUP BY time(1x)",
+			want:  &SyntaxError{Pos: 43, Msg: `invalid duration "1x"`},
+		},
+		{
+			name:  "Should return error for an invalid limit",
+			query: "SELECT * FROM trades LIMIT 0",
+			want:  &SyntaxError{Pos: 28, Msg: `invalid limit "0"`},
+		},
+		{
+			name:  "Should return error for trailing tokens",
+			query: "SELECT * FROM trades LIMIT 1 2",
+			want:  &SyntaxError{Pos: 30, Msg: `expected end of statement, found "2"`},
+		},
+		{
+			name:  "Should return error for an invalid operator",
+			query: "SELECT * FROM trades WHERE price ! 1",
+			want:  &SyntaxError{Pos: 34, Msg: `unexpected "!"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatement(tt.query)
+
+			assert.Nil(t, got)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package csvstore
+
+// PartitionCompleteness contains the completeness of the points of a
+// partition
+type PartitionCompleteness struct {
+	// From and To are the interval of the partition, limited to the range of
+	// the analysis
+	From uint64
+	To   uint64
+
+	// Rows is the number of points in the partition
+	Rows int
+
+	// Expected is the number of slots starting in the partition
+	Expected int
+
+	// Present is the number of slots starting in the partition with at least
+	// one point
+	Present int
+}
+
+// Percent returns the percentage of the expected slots with at least one
+// point, 100 if no slot is expected
+func (p *PartitionCompleteness) Percent() float64 {
+	return percent(p.Present, p.Expected)
+}
+
+func percent(present int, expected int) float64 {
+	if expected == 0 {
+		return 100
+	}
+	return float64(present) * 100 / float64(expected)
+}
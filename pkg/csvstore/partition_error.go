@@ -0,0 +1,81 @@
+package csvstore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCorruptPartition is matched by all the errors caused by invalid content
+// in a partition file
+var ErrCorruptPartition = errors.New("corrupt partition")
+
+// ErrBadTimestamp is matched by the errors caused by a timestamp that can't be
+// parsed
+var ErrBadTimestamp = errors.New("invalid timestamp")
+
+// ErrOutOfRange is matched by the errors of strict reads caused by a timestamp
+// outside the interval of its partition
+var ErrOutOfRange = errors.New("timestamp out of the partition interval")
+
+// ErrOutOfOrder is matched by the errors of strict reads caused by a
+// timestamp not greater than the one of the previous row
+var ErrOutOfOrder = errors.New("timestamp not in ascending order")
+
+// PartitionError is returned when the content of a partition file is not
+// valid. It matches ErrCorruptPartition and its Kind with errors.Is, and
+// unwraps to its cause, if any.
+type PartitionError struct {
+	// Path is the path of the partition file
+	Path string
+
+	// Line is the line of the row in the partition file, or its position for
+	// columnar files, starting from 1; it is 0 if unknown
+	Line int
+
+	// Column is the index of the invalid field in the row, or -1 if the whole
+	// row is invalid
+	Column int
+
+	// Value is the invalid value
+	Value string
+
+	// Kind is the class of the error: ErrBadTimestamp, ErrOutOfRange,
+	// ErrOutOfOrder or ErrCorruptPartition
+	Kind error
+
+	// Err is the cause of the error, i.e. a *strconv.NumError or a
+	// *csv.ParseError, or nil
+	Err error
+}
+
+func (e *PartitionError) Error() string {
+	msg := fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Kind)
+	if e.Column >= 0 {
+		msg += fmt.Sprintf(" %q in column %d", e.Value, e.Column)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is returns true if the target is ErrCorruptPartition or the kind of the
+// error
+func (e *PartitionError) Is(target error) bool {
+	return target == ErrCorruptPartition || target == e.Kind
+}
+
+// Unwrap returns the cause of the error
+func (e *PartitionError) Unwrap() error {
+	return e.Err
+}
+
+// setErrorPosition sets the path and the line in the error, if it is a
+// PartitionError without them; line returns the line of the column
+func setErrorPosition(err error, path string, line func(column int) int) {
+	var partitionErr *PartitionError
+	if errors.As(err, &partitionErr) && partitionErr.Line == 0 {
+		partitionErr.Path = path
+		partitionErr.Line = line(partitionErr.Column)
+	}
+}
@@ -0,0 +1,139 @@
+package csvstore
+
+import (
+	"encoding/csv"
+	"errors"
+	"io/ioutil"
+	"strconv"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionError(t *testing.T) {
+	numErr := &strconv.NumError{Func: "ParseUint", Num: "abc", Err: strconv.ErrSyntax}
+	parseErr := &csv.ParseError{StartLine: 2, Line: 2, Column: 1, Err: csv.ErrFieldCount}
+	tests := []struct {
+		name     string
+		err      *PartitionError
+		want     string
+		wantIs   []error
+		wantIsnt []error
+	}{
+		{
+			name:     "Should describe a bad timestamp",
+			err:      &PartitionError{Path: "some-dir/0_9.csv", Line: 3, Value: "abc", Kind: ErrBadTimestamp, Err: numErr},
+			want:     "some-dir/0_9.csv:3: invalid timestamp \"abc\" in column 0: strconv.ParseUint: parsing \"abc\": invalid syntax",
+			wantIs:   []error{ErrCorruptPartition, ErrBadTimestamp, strconv.ErrSyntax},
+			wantIsnt: []error{ErrOutOfRange, ErrOutOfOrder},
+		},
+		{
+			name:     "Should describe a timestamp out of range",
+			err:      &PartitionError{Path: "some-dir/0_9.csv", Line: 3, Value: "12", Kind: ErrOutOfRange},
+			want:     "some-dir/0_9.csv:3: timestamp out of the partition interval \"12\" in column 0",
+			wantIs:   []error{ErrCorruptPartition, ErrOutOfRange},
+			wantIsnt: []error{ErrBadTimestamp, ErrOutOfOrder},
+		},
+		{
+			name:     "Should describe an invalid row",
+			err:      &PartitionError{Path: "some-dir/0_9.csv", Line: 2, Column: -1, Kind: ErrCorruptPartition, Err: parseErr},
+			want:     "some-dir/0_9.csv:2: corrupt partition: record on line 2: wrong number of fields",
+			wantIs:   []error{ErrCorruptPartition, csv.ErrFieldCount},
+			wantIsnt: []error{ErrBadTimestamp, ErrOutOfRange, ErrOutOfOrder},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error = tt.err
+
+			assert.Equal(t, tt.want, err.Error())
+			for _, target := range tt.wantIs {
+				assert.True(t, errors.Is(err, target), target)
+			}
+			for _, target := range tt.wantIsnt {
+				assert.False(t, errors.Is(err, target), target)
+			}
+		})
+	}
+}
+
+func Test_setErrorPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "Should set the position of a PartitionError",
+			err:  &PartitionError{Column: 2, Value: "12", Kind: ErrOutOfRange},
+			want: &PartitionError{Path: "some-path", Line: 5, Column: 2, Value: "12", Kind: ErrOutOfRange},
+		},
+		{
+			name: "Should not override the position of a PartitionError",
+			err:  &PartitionError{Path: "some-other-path", Line: 7, Value: "12", Kind: ErrOutOfRange},
+			want: &PartitionError{Path: "some-other-path", Line: 7, Value: "12", Kind: ErrOutOfRange},
+		},
+		{
+			name: "Should ignore other errors",
+			err:  errors.New("some-error"),
+			want: errors.New("some-error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setErrorPosition(tt.err, "some-path", func(column int) int { return column + 3 })
+
+			assert.Equal(t, tt.want, tt.err)
+		})
+	}
+}
+
+func TestStore_LoadPoints_ShouldReturnPartitionError(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    *PartitionError
+	}{
+		{
+			name:    "Should return error if a timestamp is invalid",
+			file:    "0_9.csv",
+			content: "1,a\nabc,b\n",
+			want:    &PartitionError{Path: "0_9.csv", Line: 2, Value: "abc", Kind: ErrBadTimestamp},
+		},
+		{
+			name:    "Should return error if a row is invalid",
+			file:    "0_9.csv",
+			content: "1,a\n2,b,c\n",
+			want:    &PartitionError{Path: "0_9.csv", Line: 2, Column: -1, Kind: ErrCorruptPartition},
+		},
+		{
+			name:    "Should return error if a columnar partition is invalid",
+			file:    "0_9.col",
+			content: "invalid",
+			want:    &PartitionError{Path: "0_9.col", Column: -1, Kind: ErrCorruptPartition, Err: ErrInvalidColumnarFile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(filestest.TempDir(t), 10)
+			err := ioutil.WriteFile(s.path(tt.file), []byte(tt.content), 0644)
+			assert.Nil(t, err)
+
+			err = s.LoadPoints(0, 9, func(uint64, []string) error { return nil })
+
+			var got *PartitionError
+			assert.True(t, errors.As(err, &got))
+			assert.True(t, errors.Is(err, ErrCorruptPartition))
+			assert.True(t, errors.Is(err, tt.want.Kind))
+			assert.Equal(t, s.path(tt.want.Path), got.Path)
+			assert.Equal(t, tt.want.Line, got.Line)
+			assert.Equal(t, tt.want.Column, got.Column)
+			assert.Equal(t, tt.want.Value, got.Value)
+			if tt.want.Err != nil {
+				assert.Equal(t, tt.want.Err, got.Err)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package csvstore
+
+// PartitionIssue is a problem found verifying a partition
+type PartitionIssue int
+
+const (
+	// MissingChecksum means that the partition has no metadata, so its
+	// checksum can't be verified, e.g. if written by an older version; it is
+	// informational, and doesn't make the partition damaged
+	MissingChecksum PartitionIssue = iota + 1
+
+	// Corrupted means that the content of the partition doesn't match its
+	// checksum, or can't be parsed
+	Corrupted
+
+	// Truncated means that the partition is shorter than expected, or its last
+	// record is incomplete
+	Truncated
+
+	// OutOfOrder means that the rows of the partition are not sorted by
+	// timestamp, or contain duplicated timestamps
+	OutOfOrder
+
+	// OutOfRange means that the partition contains timestamps outside its
+	// interval
+	OutOfRange
+)
+
+func (i PartitionIssue) String() string {
+	switch i {
+	case MissingChecksum:
+		return "missing checksum"
+	case Corrupted:
+		return "corrupted"
+	case Truncated:
+		return "truncated"
+	case OutOfOrder:
+		return "out of order"
+	case OutOfRange:
+		return "out of range"
+	default:
+		return "unknown"
+	}
+}
+
+// damaging returns true if the issue means that the partition is damaged
+func (i PartitionIssue) damaging() bool {
+	return i != MissingChecksum
+}
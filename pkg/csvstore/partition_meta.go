@@ -0,0 +1,185 @@
+package csvstore
+
+import (
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// metaExtension is the extension of the sidecar files containing the
+// metadata of the partitions
+const metaExtension = ".meta"
+
+// partitionMeta contains the metadata of a partition file, stored in a
+// sidecar file, used to verify its integrity
+type partitionMeta struct {
+	File  string `json:"file"`
+	Size  int64  `json:"size"`
+	CRC32 uint32 `json:"crc32"`
+	Rows  int    `json:"rows"`
+	First uint64 `json:"first"`
+	Last  uint64 `json:"last"`
+
+	// MinStep and MaxStep are the minimum and maximum differences between the
+	// timestamps of consecutive rows, used to check the cadence of the points
+	// without reading the partition
+	MinStep uint64 `json:"minStep,omitempty"`
+	MaxStep uint64 `json:"maxStep,omitempty"`
+
+	// HasStats is true if the statistics of the columns have been computed
+	// from the first row, which is not the case for older metadata
+	HasStats bool          `json:"hasStats,omitempty"`
+	Columns  []columnStats `json:"columns,omitempty"`
+}
+
+// add updates the metadata with a new row
+func (m *partitionMeta) add(timestamp uint64, record []string) {
+	if m.Rows == 0 {
+		m.First = timestamp
+		m.HasStats = true
+	} else {
+		step := timestamp - m.Last
+		if m.Rows == 1 || step < m.MinStep {
+			m.MinStep = step
+		}
+		if step > m.MaxStep {
+			m.MaxStep = step
+		}
+	}
+	m.Last = timestamp
+	m.Rows++
+
+	if m.HasStats {
+		for len(m.Columns) < len(record) {
+			m.Columns = append(m.Columns, columnStats{})
+		}
+		for i, value := range record {
+			m.Columns[i].add(value)
+		}
+	}
+}
+
+// columns returns the statistics of the columns, nil if unknown
+func (m *partitionMeta) columns() []columnStats {
+	if !m.HasStats {
+		return nil
+	}
+	if m.Columns == nil {
+		return []columnStats{}
+	}
+	return m.Columns
+}
+
+// within returns the sorted points with timestamp between the first and the
+// last row of the partition
+func (m *partitionMeta) within(points dataPointList) dataPointList {
+	if m.Rows == 0 {
+		return nil
+	}
+	return points.between(m.First, m.Last)
+}
+
+// metaPath returns the path of the sidecar file of the partition file
+func metaPath(path string) string {
+	path = strings.TrimSuffix(path, compressionOf(path).suffix())
+	return strings.TrimSuffix(path, filepath.Ext(path)) + metaExtension
+}
+
+func readPartitionMeta(path string) (*partitionMeta, error) {
+	content, err := ioutil.ReadFile(metaPath(path))
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &partitionMeta{}
+	err = json.Unmarshal(content, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
+func writePartitionMeta(path string, meta *partitionMeta) error {
+	content, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(metaPath(path), content, 0644)
+}
+
+// currentPartitionMeta returns the metadata of the partition file, reading it
+// from the sidecar file if consistent with the file, or computing it otherwise
+func currentPartitionMeta(path string, format fileFormat) (*partitionMeta, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := readPartitionMeta(path)
+	if err == nil && meta.File == filepath.Base(path) && meta.Size == info.Size() {
+		return meta, nil
+	}
+
+	return computePartitionMeta(path, format)
+}
+
+// storedPartitionMeta returns the metadata of the partition file stored in
+// the sidecar file, nil if missing or not consistent with the file
+func storedPartitionMeta(path string) (*partitionMeta, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := readPartitionMeta(path)
+	if err != nil || meta.File != filepath.Base(path) || meta.Size != info.Size() {
+		return nil, nil
+	}
+
+	return meta, nil
+}
+
+// computePartitionMeta reads the whole partition file to compute its
+// metadata
+func computePartitionMeta(path string, format fileFormat) (*partitionMeta, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	counter := &countingWriter{writer: ioutil.Discard}
+	_, err = io.Copy(counter, file)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &partitionMeta{
+		File:  filepath.Base(path),
+		Size:  counter.count,
+		CRC32: counter.crc,
+		// the statistics are computed for all the rows
+		HasStats: true,
+	}
+	err = readRecords(path, readOptions{format: format}, newTimestampHandler(format.timestamps, func(timestamp uint64, record []string) error {
+		meta.add(timestamp, record)
+		return nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
+// checksum returns the CRC32 checksum of the content, starting from the
+// checksum of the previous content
+func checksum(crc uint32, content []byte) uint32 {
+	return crc32.Update(crc, crc32.IEEETable, content)
+}
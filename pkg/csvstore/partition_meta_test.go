@@ -0,0 +1,115 @@
+package csvstore
+
+import (
+	"hash/crc32"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/pasdam/go-io-utilx/pkg/ioutilx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_metaPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: filepath.Join("some-dir", "0_9.csv"), want: filepath.Join("some-dir", "0_9.meta")},
+		{path: filepath.Join("some-dir", "0_9.csv.gz"), want: filepath.Join("some-dir", "0_9.meta")},
+		{path: filepath.Join("some-dir", "0_9.csv.zst"), want: filepath.Join("some-dir", "0_9.meta")},
+		{path: filepath.Join("some-dir", "0_9.col"), want: filepath.Join("some-dir", "0_9.meta")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := metaPath(tt.path)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_partitionMeta_add(t *testing.T) {
+	meta := &partitionMeta{}
+
+	meta.add(3, []string{"1.5", "a"})
+	meta.add(5, []string{"-2"})
+	meta.add(9, []string{"", "b", "10"})
+
+	assert.Equal(t, &partitionMeta{
+		Rows:     3,
+		First:    3,
+		Last:     9,
+		MinStep:  2,
+		MaxStep:  4,
+		HasStats: true,
+		Columns: []columnStats{
+			{Numeric: 2, Min: -2, Max: 1.5},
+			{Other: 2},
+			{Numeric: 1, Min: 10, Max: 10},
+		},
+	}, meta)
+}
+
+func Test_partitionMeta_add_ShouldNotComputeStatsForOlderMetadata(t *testing.T) {
+	meta := &partitionMeta{Rows: 1, First: 3, Last: 3}
+
+	meta.add(5, []string{"1"})
+
+	assert.Nil(t, meta.columns())
+	assert.Nil(t, meta.Columns)
+}
+
+func Test_writePartitionMeta_readPartitionMeta(t *testing.T) {
+	path := filepath.Join(filestest.TempDir(t), "0_9.csv")
+	want := &partitionMeta{File: "0_9.csv", Size: 10, CRC32: 12345, Rows: 2, First: 1, Last: 3}
+
+	err := writePartitionMeta(path, want)
+	assert.Nil(t, err)
+
+	got, err := readPartitionMeta(path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func Test_currentPartitionMeta(t *testing.T) {
+	content := "1,some-value-at-1\n3,some-value-at-3\n"
+	tests := []struct {
+		name string
+		meta *partitionMeta
+		want *partitionMeta
+	}{
+		{
+			name: "Should return stored metadata if consistent with the file",
+			meta: &partitionMeta{File: "0_9.csv", Size: int64(len(content)), CRC32: 1, Rows: 10, First: 0, Last: 9},
+			want: &partitionMeta{File: "0_9.csv", Size: int64(len(content)), CRC32: 1, Rows: 10, First: 0, Last: 9},
+		},
+		{
+			name: "Should compute metadata if the stored one is not consistent with the file",
+			meta: &partitionMeta{File: "0_9.csv", Size: 1, CRC32: 1, Rows: 10, First: 0, Last: 9},
+			want: &partitionMeta{File: "0_9.csv", Size: int64(len(content)), CRC32: crc32.ChecksumIEEE([]byte(content)), Rows: 2, First: 1, Last: 3, MinStep: 2, MaxStep: 2, HasStats: true, Columns: []columnStats{{Other: 2}}},
+		},
+		{
+			name: "Should compute metadata if it doesn't exist",
+			want: &partitionMeta{File: "0_9.csv", Size: int64(len(content)), CRC32: crc32.ChecksumIEEE([]byte(content)), Rows: 2, First: 1, Last: 3, MinStep: 2, MaxStep: 2, HasStats: true, Columns: []columnStats{{Other: 2}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(filestest.TempDir(t), "0_9.csv")
+			err := ioutilx.ReaderToFile(strings.NewReader(content), path)
+			assert.Nil(t, err)
+			if tt.meta != nil {
+				err = writePartitionMeta(path, tt.meta)
+				assert.Nil(t, err)
+			}
+
+			got, err := currentPartitionMeta(path, fileFormat{})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package csvstore
+
+// PartitionReport contains the result of the verification of a partition
+type PartitionReport struct {
+	// Path is the path of the partition file
+	Path string
+
+	// Rows is the number of rows read from the partition
+	Rows int
+
+	// Issues contains the problems found in the partition, if any
+	Issues []PartitionIssue
+
+	// Err is the error raised reading the partition, if any
+	Err error
+}
+
+// OK returns true if no issue was found in the partition, except the
+// informational ones, i.e. MissingChecksum
+func (r *PartitionReport) OK() bool {
+	for _, issue := range r.Issues {
+		if issue.damaging() {
+			return false
+		}
+	}
+	return true
+}
+
+// Has returns true if the issue was found in the partition
+func (r *PartitionReport) Has(issue PartitionIssue) bool {
+	for _, i := range r.Issues {
+		if i == issue {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *PartitionReport) add(issue PartitionIssue) {
+	if !r.Has(issue) {
+		r.Issues = append(r.Issues, issue)
+	}
+}
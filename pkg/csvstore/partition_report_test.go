@@ -0,0 +1,35 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionReport(t *testing.T) {
+	r := &PartitionReport{}
+	assert.True(t, r.OK())
+	assert.False(t, r.Has(Truncated))
+
+	r.add(MissingChecksum)
+	assert.True(t, r.OK())
+
+	r.add(Truncated)
+	r.add(Truncated)
+	r.add(OutOfOrder)
+
+	assert.False(t, r.OK())
+	assert.True(t, r.Has(Truncated))
+	assert.True(t, r.Has(OutOfOrder))
+	assert.False(t, r.Has(Corrupted))
+	assert.Equal(t, []PartitionIssue{MissingChecksum, Truncated, OutOfOrder}, r.Issues)
+}
+
+func TestPartitionIssue_String(t *testing.T) {
+	assert.Equal(t, "missing checksum", MissingChecksum.String())
+	assert.Equal(t, "corrupted", Corrupted.String())
+	assert.Equal(t, "truncated", Truncated.String())
+	assert.Equal(t, "out of order", OutOfOrder.String())
+	assert.Equal(t, "out of range", OutOfRange.String())
+	assert.Equal(t, "unknown", PartitionIssue(0).String())
+}
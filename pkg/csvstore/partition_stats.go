@@ -0,0 +1,61 @@
+package csvstore
+
+// PartitionStats contains the statistics of a partition
+type PartitionStats struct {
+	// Path is the path of the partition file
+	Path string
+
+	// From and To are the interval of the partition
+	From uint64
+	To   uint64
+
+	// Rows is the number of rows
+	Rows int
+
+	// First and Last are the timestamps of the first and last rows
+	First uint64
+	Last  uint64
+
+	// Columns contains the statistics of each column, excluding the timestamp
+	Columns []ColumnStats
+}
+
+// ColumnStats contains the statistics of the values of a column in a
+// partition
+type ColumnStats struct {
+	// Count is the number of non empty values
+	Count int
+
+	// Nulls is the number of rows with an empty or missing value
+	Nulls int
+
+	// Numeric is true if all the non empty values are numbers
+	Numeric bool
+
+	// Min and Max are the minimum and maximum of the numeric values
+	Min float64
+	Max float64
+}
+
+// newPartitionStats returns the statistics in the metadata of the partition
+func newPartitionStats(path string, from uint64, to uint64, meta *partitionMeta) *PartitionStats {
+	stats := &PartitionStats{
+		Path:    path,
+		From:    from,
+		To:      to,
+		Rows:    meta.Rows,
+		First:   meta.First,
+		Last:    meta.Last,
+		Columns: make([]ColumnStats, len(meta.Columns)),
+	}
+	for i, c := range meta.Columns {
+		stats.Columns[i] = ColumnStats{
+			Count:   c.Numeric + c.Other,
+			Nulls:   meta.Rows - c.Numeric - c.Other,
+			Numeric: c.Numeric > 0 && c.Other == 0,
+			Min:     c.Min,
+			Max:     c.Max,
+		}
+	}
+	return stats
+}
@@ -0,0 +1,20 @@
+package csvstore
+
+// PartitionWriteResult contains the statistics of a partition file written
+// by a write operation
+type PartitionWriteResult struct {
+	InsertStats
+
+	// Path is the path of the partition file
+	Path string
+
+	// Appended is true if the rows were appended to the existing file, instead
+	// of rewriting it
+	Appended bool
+
+	// Rows is the number of rows written to the partition file
+	Rows int
+
+	// Bytes is the number of bytes written to the partition file
+	Bytes int64
+}
@@ -0,0 +1,10 @@
+package csvstore
+
+// Point is a data point of a time series
+type Point struct {
+	// Timestamp is the timestamp of the data point
+	Timestamp uint64
+
+	// Record contains the columns of the data point, excluding the timestamp
+	Record []string
+}
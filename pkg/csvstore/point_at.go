@@ -0,0 +1,73 @@
+package csvstore
+
+import (
+	"math"
+	"os"
+)
+
+// PointAt returns the point in effect at the timestamp, i.e. the last one with
+// timestamp less than or equal to it. The partitions are read stepping back
+// from the one containing the timestamp, up to maxLookback before it, or to
+// the first partition of the store if maxLookback is 0. The record is nil if
+// there is no such point.
+func (s *Store) PointAt(timestamp uint64, maxLookback uint64) (uint64, []string, error) {
+	lookup, err := s.newPointLookup(maxLookback)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	p, err := lookup.pointAt(timestamp)
+	if err != nil || p == nil {
+		return 0, nil, err
+	}
+
+	return p.timestamp, p.record, nil
+}
+
+// PointsAt is like PointAt for each of the timestamps, reading each partition
+// at most once. The points are in the same order of the timestamps, and have
+// a nil record if there is no point in effect.
+func (s *Store) PointsAt(timestamps []uint64, maxLookback uint64) ([]Point, error) {
+	lookup, err := s.newPointLookup(maxLookback)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]Point, len(timestamps))
+	for i, timestamp := range timestamps {
+		p, err := lookup.pointAt(timestamp)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			points[i] = Point{Timestamp: p.timestamp, Record: p.record}
+		}
+	}
+
+	return points, nil
+}
+
+// earliestTimestamp returns the start of the first partition of the store, or
+// the first buffered point if before it; false if the store is empty
+func (s *Store) earliestTimestamp() (uint64, bool, error) {
+	earliest, found := uint64(0), false
+
+	names, err := listDatasets(s.dir, s.index.signed)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, false, err
+	}
+	if len(names) > 0 {
+		earliest, _, _ = parseDatasetName(names[0], s.index.signed)
+		found = true
+	}
+
+	if s.buffer != nil {
+		buffered := s.buffer.snapshot(0, math.MaxUint64, s.policy)
+		if len(buffered) > 0 && (!found || buffered[0].timestamp < earliest) {
+			earliest = buffered[0].timestamp
+			found = true
+		}
+	}
+
+	return earliest, found, nil
+}
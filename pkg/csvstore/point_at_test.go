@@ -0,0 +1,101 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_PointAt(t *testing.T) {
+	tests := []struct {
+		name          string
+		timestamp     uint64
+		maxLookback   uint64
+		wantTimestamp uint64
+		wantRecord    []string
+	}{
+		{
+			name:          "Should return point at the timestamp",
+			timestamp:     11,
+			wantTimestamp: 11,
+			wantRecord:    []string{"some-value-at-11"},
+		},
+		{
+			name:          "Should return previous point in the same partition",
+			timestamp:     5,
+			wantTimestamp: 3,
+			wantRecord:    []string{"some-value-at-3"},
+		},
+		{
+			name:          "Should step back through empty partitions",
+			timestamp:     75,
+			wantTimestamp: 35,
+			wantRecord:    []string{"some-value-at-35"},
+		},
+		{
+			name:          "Should return point within the lookback",
+			timestamp:     75,
+			maxLookback:   40,
+			wantTimestamp: 35,
+			wantRecord:    []string{"some-value-at-35"},
+		},
+		{
+			name:        "Should return nil if the point is older than the lookback",
+			timestamp:   75,
+			maxLookback: 39,
+		},
+		{
+			name:      "Should return nil if there are no previous points",
+			timestamp: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newCompactTestStore(t)
+
+			gotTimestamp, gotRecord, err := s.PointAt(tt.timestamp, tt.maxLookback)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantTimestamp, gotTimestamp)
+			assert.Equal(t, tt.wantRecord, gotRecord)
+		})
+	}
+}
+
+func TestStore_PointAt_ShouldReturnNilIfStoreIsEmpty(t *testing.T) {
+	s := NewStore(filestest.TempDir(t), 10)
+
+	gotTimestamp, gotRecord, err := s.PointAt(100, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), gotTimestamp)
+	assert.Nil(t, gotRecord)
+}
+
+func TestStore_PointAt_ShouldIncludeBufferedPoints(t *testing.T) {
+	s := NewStore(filestest.TempDir(t), 10, WithWriteBuffer(100, 0))
+	defer s.Close()
+	err := s.Append(42, []string{"some-buffered-value"})
+	assert.Nil(t, err)
+
+	gotTimestamp, gotRecord, err := s.PointAt(100, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(42), gotTimestamp)
+	assert.Equal(t, []string{"some-buffered-value"}, gotRecord)
+}
+
+func TestStore_PointsAt(t *testing.T) {
+	s := newCompactTestStore(t)
+
+	got, err := s.PointsAt([]uint64{0, 2, 19, 100}, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Point{
+		{},
+		{Timestamp: 1, Record: []string{"some-value-at-1"}},
+		{Timestamp: 11, Record: []string{"some-value-at-11"}},
+		{Timestamp: 35, Record: []string{"some-value-at-35"}},
+	}, got)
+}
@@ -0,0 +1,82 @@
+package csvstore
+
+// pointLookup finds the points in effect at some timestamps, caching the
+// partitions read
+type pointLookup struct {
+	store       *Store
+	maxLookback uint64
+	earliest    uint64
+	empty       bool
+	partitions  map[uint64][]*dataPoint
+}
+
+func (s *Store) newPointLookup(maxLookback uint64) (*pointLookup, error) {
+	earliest, found, err := s.earliestTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pointLookup{
+		store:       s,
+		maxLookback: maxLookback,
+		earliest:    earliest,
+		empty:       !found,
+		partitions:  make(map[uint64][]*dataPoint),
+	}, nil
+}
+
+// pointAt returns the last point with timestamp less than or equal to the
+// specified one, nil if there is none within the lookback
+func (l *pointLookup) pointAt(timestamp uint64) (*dataPoint, error) {
+	if l.empty {
+		return nil, nil
+	}
+
+	floor := l.earliest
+	if l.maxLookback > 0 && timestamp >= l.maxLookback && timestamp-l.maxLookback > floor {
+		floor = timestamp - l.maxLookback
+	}
+	if timestamp < floor {
+		return nil, nil
+	}
+
+	from, _ := timestampToInterval(timestamp, l.store.index.interval, l.store.index.signed)
+	for {
+		points, err := l.partition(from)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := len(points) - 1; i >= 0; i-- {
+			if points[i].timestamp > timestamp {
+				continue
+			}
+			if points[i].timestamp < floor {
+				return nil, nil
+			}
+			return points[i], nil
+		}
+
+		if from <= floor || from == 0 {
+			return nil, nil
+		}
+		from, _ = timestampToInterval(from-1, l.store.index.interval, l.store.index.signed)
+	}
+}
+
+// partition returns the points of the partition starting at from
+func (l *pointLookup) partition(from uint64) ([]*dataPoint, error) {
+	points, ok := l.partitions[from]
+	if ok {
+		return points, nil
+	}
+
+	to := from + l.store.index.interval - 1
+	err := l.store.LoadPoints(from, to, newRecordsCollector(&points))
+	if err != nil {
+		return nil, err
+	}
+
+	l.partitions[from] = points
+	return points, nil
+}
@@ -0,0 +1,29 @@
+package csvstore
+
+// Points is a TimeSeries backed by a slice of Point
+type Points []Point
+
+// Len returns the number of points
+func (p Points) Len() int {
+	return len(p)
+}
+
+// Less returns true if the point at index i is before the one at index j
+func (p Points) Less(i, j int) bool {
+	return p[i].Timestamp < p[j].Timestamp
+}
+
+// Swap swaps the points at the specified indexes
+func (p Points) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// CsvAtIndex returns the record of the point at the specified index
+func (p Points) CsvAtIndex(index int) []string {
+	return p[index].Record
+}
+
+// TimestampAtIndex returns the timestamp of the point at the specified index
+func (p Points) TimestampAtIndex(index int) uint64 {
+	return p[index].Timestamp
+}
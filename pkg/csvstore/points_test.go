@@ -0,0 +1,26 @@
+package csvstore
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoints(t *testing.T) {
+	p := Points{
+		{Timestamp: 2, Record: []string{"some-value-at-2"}},
+		{Timestamp: 0, Record: []string{"some-value-at-0"}},
+		{Timestamp: 1, Record: []string{"some-value-at-1"}},
+	}
+
+	sort.Sort(p)
+
+	assert.Equal(t, 3, p.Len())
+	assert.Equal(t, uint64(0), p.TimestampAtIndex(0))
+	assert.Equal(t, []string{"some-value-at-0"}, p.CsvAtIndex(0))
+	assert.Equal(t, uint64(1), p.TimestampAtIndex(1))
+	assert.Equal(t, []string{"some-value-at-1"}, p.CsvAtIndex(1))
+	assert.Equal(t, uint64(2), p.TimestampAtIndex(2))
+	assert.Equal(t, []string{"some-value-at-2"}, p.CsvAtIndex(2))
+}
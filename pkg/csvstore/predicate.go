@@ -0,0 +1,32 @@
+package csvstore
+
+import "errors"
+
+// ErrInvalidPredicate is returned when the value of a comparison is not valid
+// for the type of the column
+var ErrInvalidPredicate = errors.New("invalid predicate")
+
+// Predicate filters the data points by the values of their columns; it is
+// created with Compare, and composed with And, Or and Not
+type Predicate interface {
+	// bind resolves the columns of the predicate with the schema
+	bind(schema Schema) (rowFilter, error)
+}
+
+// rowFilter is a predicate whose columns have been resolved to indexes in the
+// records
+type rowFilter interface {
+	// match returns true if the record satisfies the predicate
+	match(record []string) bool
+
+	// mayMatch returns false if no record of a partition with the statistics
+	// of the columns can satisfy the predicate
+	mayMatch(stats []columnStats) bool
+
+	// columns returns the indexes of the columns used
+	columns() []int
+
+	// remap returns the filter for records containing only some of the
+	// columns, in the positions specified
+	remap(positions map[int]int) rowFilter
+}
@@ -0,0 +1,90 @@
+package csvstore
+
+// qlExpr is a condition of the WHERE clause of a statement
+type qlExpr interface{}
+
+// qlCompare compares a column, or the time, with a literal
+type qlCompare struct {
+	column qlToken
+	op     CompareOp
+	value  qlToken
+}
+
+type qlAnd []qlExpr
+
+type qlOr []qlExpr
+
+type qlNot struct {
+	expr qlExpr
+}
+
+// compileWhere returns the predicate of the condition, and the bounds of the
+// time, which can only be combined with AND at the top level
+func compileWhere(expr qlExpr) (Predicate, []*timeBound, error) {
+	conditions := []qlExpr{expr}
+	if and, ok := expr.(qlAnd); ok {
+		conditions = and
+	}
+
+	var predicates []Predicate
+	var bounds []*timeBound
+	for _, condition := range conditions {
+		if c, ok := condition.(*qlCompare); ok && c.column.is("time") {
+			bound, err := newTimeBound(c.op, c.value)
+			if err != nil {
+				return nil, nil, err
+			}
+			bounds = append(bounds, bound)
+			continue
+		}
+
+		predicate, err := compilePredicate(condition)
+		if err != nil {
+			return nil, nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+
+	switch len(predicates) {
+	case 0:
+		return nil, bounds, nil
+	case 1:
+		return predicates[0], bounds, nil
+	default:
+		return And(predicates...), bounds, nil
+	}
+}
+
+func compilePredicate(expr qlExpr) (Predicate, error) {
+	switch e := expr.(type) {
+	case *qlCompare:
+		if e.column.is("time") {
+			return nil, &SyntaxError{Pos: e.column.pos, Msg: "time conditions can only be combined with AND"}
+		}
+		return Compare(e.column.text, e.op, e.value.text), nil
+
+	case qlAnd:
+		predicates, err := compilePredicates(e)
+		return And(predicates...), err
+
+	case qlOr:
+		predicates, err := compilePredicates(e)
+		return Or(predicates...), err
+
+	default:
+		predicate, err := compilePredicate(e.(*qlNot).expr)
+		return Not(predicate), err
+	}
+}
+
+func compilePredicates(exprs []qlExpr) ([]Predicate, error) {
+	predicates := make([]Predicate, len(exprs))
+	for i, expr := range exprs {
+		predicate, err := compilePredicate(expr)
+		if err != nil {
+			return nil, err
+		}
+		predicates[i] = predicate
+	}
+	return predicates, nil
+}
@@ -0,0 +1,238 @@
+package csvstore
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// qlParser parses the tokens of a statement of the query language
+type qlParser struct {
+	tokens []qlToken
+	next   int
+}
+
+func (p *qlParser) peek() qlToken {
+	return p.tokens[p.next]
+}
+
+func (p *qlParser) advance() qlToken {
+	token := p.tokens[p.next]
+	if token.kind != qlEOF {
+		p.next++
+	}
+	return token
+}
+
+// accept consumes the next token if it is the keyword
+func (p *qlParser) accept(keyword string) bool {
+	if p.peek().is(keyword) {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+// expectKeyword consumes the keyword, failing if the next token is different
+func (p *qlParser) expectKeyword(keyword string) error {
+	if !p.accept(keyword) {
+		return p.unexpected(keyword)
+	}
+	return nil
+}
+
+// expect consumes the next token, failing if it is not of the kind
+func (p *qlParser) expect(kind qlTokenKind, what string) (qlToken, error) {
+	if p.peek().kind != kind {
+		return qlToken{}, p.unexpected(what)
+	}
+	return p.advance(), nil
+}
+
+func (p *qlParser) unexpected(what string) error {
+	token := p.peek()
+	return &SyntaxError{Pos: token.pos, Msg: fmt.Sprintf("expected %s, found %s", what, token)}
+}
+
+// parseFields parses the list of the columns or of the aggregations selected
+func (p *qlParser) parseFields(stmt *Statement) error {
+	if p.peek().kind == qlStar {
+		p.advance()
+		return nil
+	}
+
+	for {
+		name, err := p.expect(qlIdent, "column or aggregation")
+		if err != nil {
+			return err
+		}
+
+		if p.peek().kind != qlLeftParen {
+			if len(stmt.Aggregates) > 0 {
+				return &SyntaxError{Pos: name.pos, Msg: "columns cannot be selected with aggregations"}
+			}
+			stmt.Columns = append(stmt.Columns, name.text)
+		} else {
+			aggregate, err := p.parseAggregate(name)
+			if err != nil {
+				return err
+			}
+			if len(stmt.Columns) > 0 {
+				return &SyntaxError{Pos: name.pos, Msg: "aggregations cannot be selected with columns"}
+			}
+			stmt.Aggregates = append(stmt.Aggregates, *aggregate)
+		}
+
+		if p.peek().kind != qlComma {
+			return nil
+		}
+		p.advance()
+	}
+}
+
+// parseAggregate parses an aggregation, e.g. mean(price) or count(*)
+func (p *qlParser) parseAggregate(name qlToken) (*Aggregate, error) {
+	aggregation, err := ParseAggregation(name.text)
+	if err != nil {
+		return nil, &SyntaxError{Pos: name.pos, Msg: "unknown aggregation " + name.String()}
+	}
+	p.advance()
+
+	column := p.peek()
+	switch {
+	case column.kind == qlStar && aggregation == Count:
+		column.text = AllRows
+	case column.kind == qlIdent || column.kind == qlNumber:
+	default:
+		return nil, p.unexpected("column")
+	}
+	p.advance()
+
+	_, err = p.expect(qlRightParen, `")"`)
+	if err != nil {
+		return nil, err
+	}
+	return &Aggregate{Func: aggregation, Column: column.text}, nil
+}
+
+// parseOr parses a condition, where AND has a higher precedence than OR
+func (p *qlParser) parseOr() (qlExpr, error) {
+	return p.parseList("OR", p.parseAnd, func(exprs []qlExpr) qlExpr { return qlOr(exprs) })
+}
+
+func (p *qlParser) parseAnd() (qlExpr, error) {
+	return p.parseList("AND", p.parseNot, func(exprs []qlExpr) qlExpr { return qlAnd(exprs) })
+}
+
+func (p *qlParser) parseList(keyword string, parse func() (qlExpr, error), combine func([]qlExpr) qlExpr) (qlExpr, error) {
+	expr, err := parse()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []qlExpr{expr}
+	for p.accept(keyword) {
+		expr, err = parse()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return combine(exprs), nil
+}
+
+func (p *qlParser) parseNot() (qlExpr, error) {
+	if p.accept("NOT") {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &qlNot{expr: expr}, nil
+	}
+
+	if p.peek().kind == qlLeftParen {
+		p.advance()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(qlRightParen, `")"`)
+		return expr, err
+	}
+
+	return p.parseCompare()
+}
+
+// parseCompare parses the comparison of a column with a literal
+func (p *qlParser) parseCompare() (qlExpr, error) {
+	column := p.peek()
+	if column.kind != qlIdent && column.kind != qlNumber {
+		return nil, p.unexpected("column")
+	}
+	p.advance()
+
+	operator, err := p.expect(qlOperator, "comparison operator")
+	if err != nil {
+		return nil, err
+	}
+
+	value := p.peek()
+	if value.kind != qlString && value.kind != qlNumber {
+		return nil, p.unexpected("string or number")
+	}
+	p.advance()
+
+	return &qlCompare{column: column, op: compareOperators[operator.text], value: value}, nil
+}
+
+// compareOperators are the comparison operators of the query language
+var compareOperators = map[string]CompareOp{
+	"=":  Equal,
+	"==": Equal,
+	"!=": NotEqual,
+	"<>": NotEqual,
+	"<":  Less,
+	"<=": LessOrEqual,
+	">":  Greater,
+	">=": GreaterOrEqual,
+}
+
+// parseGroupBy parses the duration of GROUP BY time(duration)
+func (p *qlParser) parseGroupBy(stmt *Statement) error {
+	err := p.expectKeyword("BY")
+	if err != nil {
+		return err
+	}
+	err = p.expectKeyword("time")
+	if err != nil {
+		return err
+	}
+	_, err = p.expect(qlLeftParen, `"("`)
+	if err != nil {
+		return err
+	}
+	step, err := p.expect(qlNumber, "duration")
+	if err != nil {
+		return err
+	}
+	_, err = p.expect(qlRightParen, `")"`)
+	if err != nil {
+		return err
+	}
+
+	units, err := strconv.ParseUint(step.text, 10, 64)
+	if err == nil && units > 0 {
+		stmt.stepUnits = units
+		return nil
+	}
+	duration, err := time.ParseDuration(step.text)
+	if err != nil || duration <= 0 {
+		return &SyntaxError{Pos: step.pos, Msg: "invalid duration " + step.String()}
+	}
+	stmt.step = duration
+	return nil
+}
@@ -0,0 +1,48 @@
+package csvstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// qlTokenKind is the kind of a token of the query language
+type qlTokenKind int
+
+const (
+	qlEOF qlTokenKind = iota
+	qlIdent
+	qlNumber
+	qlString
+	qlOperator
+	qlLeftParen
+	qlRightParen
+	qlComma
+	qlStar
+)
+
+// qlPunctuation contains the kinds of the single character tokens
+var qlPunctuation = map[rune]qlTokenKind{'(': qlLeftParen, ')': qlRightParen, ',': qlComma, '*': qlStar}
+
+// qlToken is a token of the query language
+type qlToken struct {
+	kind qlTokenKind
+	text string
+	pos  int
+}
+
+// is returns true if the token is the keyword, case insensitive
+func (t qlToken) is(keyword string) bool {
+	return t.kind == qlIdent && strings.EqualFold(t.text, keyword)
+}
+
+// String returns the token as shown in the error messages
+func (t qlToken) String() string {
+	switch t.kind {
+	case qlEOF:
+		return "end of statement"
+	case qlString:
+		return fmt.Sprintf("string %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
@@ -0,0 +1,17 @@
+package csvstore
+
+// QuarantinedLine is a line removed from a partition by Repair, since it
+// can't be parsed
+type QuarantinedLine struct {
+	// Path is the path of the partition file containing the line
+	Path string
+
+	// Line is the number of the line in the partition file, starting from 1
+	Line int
+
+	// Content is the content of the line
+	Content string
+
+	// Err is the reason the line can't be parsed
+	Err error
+}
@@ -0,0 +1,20 @@
+package csvstore
+
+// Query selects the data points of a store
+type Query struct {
+	// From and To are the range of the timestamps of the points
+	From uint64
+	To   uint64
+
+	// Columns are the indexes of the columns returned, excluding the
+	// timestamp
+	Columns []int
+
+	// Names are the names of the columns returned, after the ones in Columns,
+	// resolved with the schema of the store. All the columns are returned if
+	// both Columns and Names are empty.
+	Names []string
+
+	// Where filters the points by the values of their columns, if not nil
+	Where Predicate
+}
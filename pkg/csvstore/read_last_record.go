@@ -0,0 +1,86 @@
+package csvstore
+
+import (
+	"bytes"
+	"os"
+)
+
+const tailChunkSize = 4096
+
+// readLastRecord reads the last record of the CSV file, without parsing the
+// whole content if it is not compressed. It returns ok = false if the last
+// record can't be determined, i.e. if the last line is not terminated, not
+// valid, or contains quotes, so it may be part of a multiline field; a nil
+// record is returned if the file is empty.
+func readLastRecord(path string, dialect Dialect) (record []string, ok bool, err error) {
+	if compressionOf(path) != NoCompression {
+		return readLastCompressedRecord(path, dialect)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+
+	offset := info.Size()
+	if offset == 0 {
+		return nil, true, nil
+	}
+
+	// read the file backward until the beginning of the last line is found
+	var tail []byte
+	for {
+		size := int64(tailChunkSize)
+		if size > offset {
+			size = offset
+		}
+		offset -= size
+
+		chunk := make([]byte, size, size+int64(len(tail)))
+		_, err = file.ReadAt(chunk, offset)
+		if err != nil {
+			return nil, false, err
+		}
+		tail = append(chunk, tail...)
+
+		if tail[len(tail)-1] != '\n' {
+			return nil, false, nil
+		}
+
+		start := bytes.LastIndexByte(tail[:len(tail)-1], '\n')
+		if start >= 0 || offset == 0 {
+			// the line may be the end of a quoted field spanning multiple lines
+			line := tail[start+1:]
+			if dialect.LazyQuotes || bytes.IndexByte(line, '"') >= 0 {
+				return nil, false, nil
+			}
+
+			record, err = dialect.newReader(bytes.NewReader(line)).Read()
+			if err != nil {
+				return nil, false, nil
+			}
+			return record, true, nil
+		}
+	}
+}
+
+func readLastCompressedRecord(path string, dialect Dialect) (record []string, ok bool, err error) {
+	err = readRecords(path, readOptions{format: fileFormat{dialect: dialect}}, func(r []string) error {
+		record = r
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, err
+		}
+		return nil, false, nil
+	}
+
+	return record, true, nil
+}
@@ -0,0 +1,104 @@
+package csvstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/pasdam/go-io-utilx/pkg/ioutilx"
+	"github.com/pasdam/mockit/mockit"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readLastRecord(t *testing.T) {
+	type mocks struct {
+		openErr error
+	}
+	tests := []struct {
+		name    string
+		mocks   mocks
+		dialect Dialect
+		content string
+		want    []string
+		wantOk  bool
+		wantErr error
+	}{
+		{
+			name: "Should return error if os.Open raises it",
+			mocks: mocks{
+				openErr: errors.New("some-open-error"),
+			},
+			wantErr: errors.New("some-open-error"),
+		},
+		{
+			name:    "Should return nil record if the file is empty",
+			content: "",
+			wantOk:  true,
+		},
+		{
+			name:    "Should return the only record",
+			content: "1,some-value-at-1\n",
+			want:    []string{"1", "some-value-at-1"},
+			wantOk:  true,
+		},
+		{
+			name:    "Should return the last record",
+			content: "1,some-value-at-1\n2,some-value-at-2\n",
+			want:    []string{"2", "some-value-at-2"},
+			wantOk:  true,
+		},
+		{
+			name:    "Should return the last record if it is longer than the chunk size",
+			content: "1,some-value-at-1\n2," + strings.Repeat("a", tailChunkSize*2) + "\n",
+			want:    []string{"2", strings.Repeat("a", tailChunkSize*2)},
+			wantOk:  true,
+		},
+		{
+			name:    "Should return the last record terminated by CRLF",
+			content: "1,some-value-at-1\r\n2,some-value-at-2\r\n",
+			want:    []string{"2", "some-value-at-2"},
+			wantOk:  true,
+		},
+		{
+			name:    "Should not be ok if the last line is not terminated",
+			content: "1,some-value-at-1\n2,some-val",
+			wantOk:  false,
+		},
+		{
+			name:    "Should not be ok if the last line is invalid",
+			content: "1,some-value-at-1\n2,some\"value\n",
+			wantOk:  false,
+		},
+		{
+			name:    "Should not be ok if the last line may be part of a multiline field",
+			content: "1,\"some\n2,value\"\n",
+			wantOk:  false,
+		},
+		{
+			name:    "Should not be ok with lazy quotes",
+			dialect: Dialect{LazyQuotes: true},
+			content: "1,some\n2,value\n",
+			wantOk:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(filestest.TempDir(t), "0_9.csv")
+			if tt.mocks.openErr != nil {
+				mockit.MockFunc(t, os.Open).With(path).Return(nil, tt.mocks.openErr)
+			} else {
+				err := ioutilx.ReaderToFile(strings.NewReader(tt.content), path)
+				assert.Nil(t, err)
+			}
+
+			got, ok, err := readLastRecord(path, tt.dialect)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package csvstore
+
+import (
+	"errors"
+)
+
+// readOptions configures how the partition files are read
+type readOptions struct {
+	// malformed is called for each row that can't be parsed: the row is
+	// skipped if it returns nil, otherwise the read is aborted with the
+	// returned error. If nil, the read is aborted with the error of the row.
+	malformed func(*PartitionError) error
+
+	// format is the format of the CSV files
+	format fileFormat
+
+	// columns are the indexes of the columns to read, excluding the
+	// timestamp, or nil to read all of them
+	columns []int
+}
+
+// handleRowError returns the error to abort the read with, or nil if the row
+// that raised the error has to be skipped
+func (o readOptions) handleRowError(err error) error {
+	var partitionErr *PartitionError
+	if o.malformed == nil || !errors.As(err, &partitionErr) {
+		return err
+	}
+	return o.malformed(partitionErr)
+}
@@ -0,0 +1,49 @@
+package csvstore
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readOptions_handleRowError(t *testing.T) {
+	partitionErr := &PartitionError{Value: "abc", Kind: ErrBadTimestamp}
+	tests := []struct {
+		name      string
+		malformed func(*PartitionError) error
+		err       error
+		want      error
+	}{
+		{
+			name: "Should return the error if malformed is not set",
+			err:  partitionErr,
+			want: partitionErr,
+		},
+		{
+			name:      "Should return nil if malformed skips the row",
+			malformed: func(*PartitionError) error { return nil },
+			err:       partitionErr,
+		},
+		{
+			name:      "Should return the error of malformed",
+			malformed: func(*PartitionError) error { return errors.New("some-malformed-error") },
+			err:       partitionErr,
+			want:      errors.New("some-malformed-error"),
+		},
+		{
+			name:      "Should return other errors",
+			malformed: func(*PartitionError) error { return nil },
+			err:       io.EOF,
+			want:      io.EOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readOptions{malformed: tt.malformed}.handleRowError(tt.err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
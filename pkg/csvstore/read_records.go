@@ -2,27 +2,55 @@ package csvstore
 
 import (
 	"encoding/csv"
+	"errors"
 	"os"
 )
 
-func readRecords(path string, recordHandler func([]string) error) error {
+func readRecords(path string, opts readOptions, recordHandler func([]string) error) error {
 	file, err := os.Open(path)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	decompressed, err := compressionOf(path).newReader(file)
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+
 	// create CSV reader from file
-	reader := csv.NewReader(file)
+	reader := opts.format.dialect.newReader(decompressed)
+	// the projection copies the columns needed, so the record can be reused
+	reader.ReuseRecord = opts.columns != nil
 	for {
 		record, err := reader.Read()
 		if err != nil {
-			return errOrNilIfEOF(err)
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return errOrNilIfEOF(err)
+			}
+
+			err = opts.handleRowError(&PartitionError{Path: path, Line: parseErr.StartLine, Column: -1, Kind: ErrCorruptPartition, Err: err})
+			if err != nil {
+				return err
+			}
+			continue
 		}
 
 		err = recordHandler(record)
 		if err != nil {
-			return errOrNilIfEOF(err)
+			setErrorPosition(err, path, func(column int) int {
+				if column < 0 || column >= len(record) {
+					column = 0
+				}
+				line, _ := reader.FieldPos(column)
+				return line
+			})
+			err = opts.handleRowError(err)
+			if err != nil {
+				return errOrNilIfEOF(err)
+			}
 		}
 	}
 }
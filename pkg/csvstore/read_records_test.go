@@ -43,7 +43,7 @@ func Test_readRecords(t *testing.T) {
 			want: [][]string{
 				{"some", "invalid"},
 			},
-			wantErr: errors.New("record on line 2: wrong number of fields"),
+			wantErr: errors.New("testdata/csv/invalid.csv:2: corrupt partition: record on line 2: wrong number of fields"),
 		},
 		{
 			name: "Should return error if handler raises it",
@@ -81,7 +81,7 @@ func Test_readRecords(t *testing.T) {
 				return tt.mocks.handlerErr
 			}
 
-			err := readRecords(tt.args.path, handler)
+			err := readRecords(tt.args.path, readOptions{}, handler)
 
 			if tt.wantErr != nil {
 				assert.NotNil(t, err)
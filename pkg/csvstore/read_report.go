@@ -0,0 +1,21 @@
+package csvstore
+
+// ReadReport contains the diagnostics of a read
+type ReadReport struct {
+	// Skipped contains the errors of the rows skipped since malformed, if
+	// the store is configured with CollectMalformed
+	Skipped []*PartitionError
+
+	// Pruned is the number of partitions not read since the statistics in
+	// their metadata show that none of their points matches the predicate
+	Pruned int
+
+	// Summarized is the number of partitions not read since aggregated using
+	// the statistics in their metadata
+	Summarized int
+}
+
+// OK returns true if no row was skipped
+func (r *ReadReport) OK() bool {
+	return len(r.Skipped) == 0
+}
@@ -0,0 +1,12 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadReport_OK(t *testing.T) {
+	assert.True(t, (&ReadReport{}).OK())
+	assert.False(t, (&ReadReport{Skipped: []*PartitionError{{}}}).OK())
+}
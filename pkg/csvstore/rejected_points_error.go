@@ -0,0 +1,23 @@
+package csvstore
+
+import "fmt"
+
+// RejectedPointsError is returned when flushing the write buffer if the
+// conflict policy rejects some of the buffered points: the rejected points are
+// discarded, and the others persisted. It unwraps to the error of the policy
+// for the first rejected point.
+type RejectedPointsError struct {
+	// Timestamps are the timestamps of the rejected points
+	Timestamps []uint64
+
+	// Err is the error of the policy for the first rejected point
+	Err error
+}
+
+func (e *RejectedPointsError) Error() string {
+	return fmt.Sprintf("%d buffered points rejected: %v", len(e.Timestamps), e.Err)
+}
+
+func (e *RejectedPointsError) Unwrap() error {
+	return e.Err
+}
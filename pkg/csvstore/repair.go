@@ -0,0 +1,272 @@
+package csvstore
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// quarantineDir is the folder, inside the store one, where Repair moves the
+// lines that can't be parsed
+const quarantineDir = "quarantine"
+
+// quarantineExtension is the extension of the files in the quarantine folder
+const quarantineExtension = ".quarantine"
+
+// Repair fixes the partitions of the store that have been damaged, i.e. edited
+// by hand: it sorts their rows, keeps the last of the rows with the same
+// timestamp, regardless of the conflict policy of the store, moves the rows to
+// the partition of their timestamp, and moves the lines that can't be parsed,
+// or are truncated, to the quarantine folder, as CSV records with the line
+// number and content.
+// Only the partitions with issues are rewritten, atomically.
+// If the write buffer is enabled, it is flushed before the repair.
+func (s *Store) Repair() (*RepairReport, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, err := s.flush()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := listDatasets(s.dir, s.index.signed)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RepairReport{}
+	partitions := make(map[uint64]*repairPartition)
+	partitionOf := func(timestamp uint64) (uint64, *repairPartition) {
+		from, to := timestampToInterval(timestamp, s.index.interval, s.index.signed)
+		p, ok := partitions[from]
+		if !ok {
+			p = &repairPartition{from: from, to: to}
+			partitions[from] = p
+		}
+		return from, p
+	}
+
+	fields := -1
+	if s.dialect.FieldsPerRecord > 0 {
+		fields = s.dialect.FieldsPerRecord
+	}
+	for _, name := range names {
+		path := s.path(name)
+		from, to, _ := parseDatasetName(name, s.index.signed)
+		key, source := partitionOf(from)
+		source.sources = append(source.sources, path)
+		if len(source.sources) > 1 || source.from != from || source.to != to {
+			source.dirty = true
+		}
+
+		var quarantined []QuarantinedLine
+		rows := 0
+		var previous uint64
+		err = s.scanRepairRows(path, &fields, func(timestamp uint64, record []string) {
+			if rows > 0 && timestamp <= previous {
+				source.dirty = true
+			}
+			previous = timestamp
+			rows++
+
+			target, p := partitionOf(timestamp)
+			if target != key {
+				p.moved = append(p.moved, &dataPoint{timestamp: timestamp, record: record})
+				p.dirty = true
+				source.dirty = true
+				report.Moved++
+			}
+		}, func(line QuarantinedLine) {
+			quarantined = append(quarantined, line)
+		})
+		if err != nil {
+			return report, err
+		}
+
+		if len(quarantined) > 0 {
+			err = s.quarantine(name, quarantined)
+			if err != nil {
+				return report, err
+			}
+			report.Quarantined = append(report.Quarantined, quarantined...)
+			source.dirty = true
+		}
+	}
+
+	keys := make([]uint64, 0, len(partitions))
+	for key, p := range partitions {
+		if p.dirty {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	for _, key := range keys {
+		err = s.repairPartition(key, partitions[key], fields, report)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// repairPartition rewrites the partition with its own rows and the ones moved
+// into it, or removes it if it has no rows left
+func (s *Store) repairPartition(key uint64, p *repairPartition, fields int, report *RepairReport) error {
+	points := make(dataPointList, 0, len(p.moved))
+	for _, source := range p.sources {
+		err := s.scanRepairRows(source, &fields, func(timestamp uint64, record []string) {
+			from, _ := timestampToInterval(timestamp, s.index.interval, s.index.signed)
+			if from == key {
+				points = append(points, &dataPoint{timestamp: timestamp, record: record})
+			}
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	points = append(points, p.moved...)
+
+	// the conflict policy of the store may reject the duplicates, so the last
+	// one in the file is kept
+	sorted, stats, err := sortPoints(points, ReplaceOnConflict)
+	if err != nil {
+		return err
+	}
+	report.Duplicates += stats.Replaced + stats.Skipped
+
+	path := ""
+	if len(sorted) > 0 {
+		path = s.path(datasetName(p.from, p.to, s.index.signed)) + s.compression.suffix()
+		if len(p.sources) == 1 && !isColumnar(p.sources[0]) && metaPath(p.sources[0]) == metaPath(path) {
+			// keep the compression of the existing file
+			path = p.sources[0]
+		}
+
+		_, err = writeDataset(&dataset{path: path, points: sorted, format: s.fileFormat()})
+		if err != nil {
+			return err
+		}
+		report.Rewritten = append(report.Rewritten, path)
+	}
+
+	for _, source := range p.sources {
+		if source == path {
+			continue
+		}
+
+		err = os.Remove(source)
+		if err != nil {
+			return err
+		}
+		report.Removed = append(report.Removed, source)
+
+		if len(path) == 0 || metaPath(source) != metaPath(path) {
+			err = os.Remove(metaPath(source))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// scanRepairRows reads the rows of the partition file, tolerating the invalid
+// ones: the handler is called for each valid row, and quarantine, if not nil,
+// for each line that can't be parsed. The number of fields is set by the first
+// valid row, if negative.
+func (s *Store) scanRepairRows(path string, fields *int, handler func(uint64, []string), quarantine func(QuarantinedLine)) error {
+	if isColumnar(path) {
+		return readColumnar(path, readOptions{}, func(timestamp uint64, record []string) error {
+			handler(timestamp, record)
+			return nil
+		})
+	}
+
+	return scanLines(path, func(line int, content string, terminated bool) error {
+		record, err := parseRepairLine(content, terminated, s.dialect)
+		if err == nil && record == nil {
+			// empty line
+			return nil
+		}
+		if err == nil && s.dialect.FieldsPerRecord >= 0 && *fields >= 0 && len(record) != *fields {
+			err = csv.ErrFieldCount
+		}
+
+		var timestamp uint64
+		var rest []string
+		if err == nil {
+			timestamp, rest, err = s.fileFormat().timestamps.parse(record)
+		}
+
+		if err != nil {
+			setErrorPosition(err, path, func(int) int { return line })
+			if quarantine != nil {
+				quarantine(QuarantinedLine{Path: path, Line: line, Content: content, Err: err})
+			}
+			return nil
+		}
+
+		if *fields < 0 {
+			*fields = len(record)
+		}
+		handler(timestamp, rest)
+		return nil
+	})
+}
+
+// parseRepairLine parses the content of a single CSV record, returning nil if
+// it is empty or a comment
+func parseRepairLine(content string, terminated bool, dialect Dialect) ([]string, error) {
+	if !terminated {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	reader := dialect.newReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	record, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// quarantine appends the lines to the quarantine file of the partition
+func (s *Store) quarantine(name string, lines []QuarantinedLine) error {
+	dir := filepath.Join(s.dir, quarantineDir)
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, name+quarantineExtension), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	for _, line := range lines {
+		err = writer.Write([]string{strconv.Itoa(line.Line), line.Content, line.Err.Error()})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,16 @@
+package csvstore
+
+// repairPartition contains the state of a partition collected by Repair
+type repairPartition struct {
+	from uint64
+	to   uint64
+
+	// sources are the existing files of the partition
+	sources []string
+
+	// moved are the rows moved into the partition from other files
+	moved dataPointList
+
+	// dirty is true if the partition needs to be rewritten
+	dirty bool
+}
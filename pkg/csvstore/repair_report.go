@@ -0,0 +1,27 @@
+package csvstore
+
+// RepairReport contains the result of the repair of a store
+type RepairReport struct {
+	// Rewritten contains the paths of the partition files that were rewritten
+	Rewritten []string
+
+	// Removed contains the paths of the partition files that were removed,
+	// since their rows were moved to other files
+	Removed []string
+
+	// Moved is the number of rows moved to the partition of their timestamp
+	Moved int
+
+	// Duplicates is the number of rows removed since their timestamp was
+	// already present
+	Duplicates int
+
+	// Quarantined contains the lines that couldn't be parsed, that were moved
+	// to the quarantine folder
+	Quarantined []QuarantinedLine
+}
+
+// OK returns true if the store didn't need any repair
+func (r *RepairReport) OK() bool {
+	return len(r.Rewritten) == 0 && len(r.Removed) == 0
+}
@@ -0,0 +1,13 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepairReport_OK(t *testing.T) {
+	assert.True(t, (&RepairReport{}).OK())
+	assert.False(t, (&RepairReport{Rewritten: []string{"0_9.csv"}}).OK())
+	assert.False(t, (&RepairReport{Removed: []string{"0_9.csv"}}).OK())
+}
@@ -0,0 +1,178 @@
+package csvstore
+
+import (
+	"encoding/csv"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/pasdam/go-io-utilx/pkg/ioutilx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Repair(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []Option
+		files          map[string]string
+		want           *RepairReport
+		wantFiles      map[string]string
+		wantQuarantine map[string]string
+	}{
+		{
+			name: "Should not rewrite valid partitions",
+			files: map[string]string{
+				"0_9.csv": "1,a\n2,b\n",
+			},
+			want: &RepairReport{},
+			wantFiles: map[string]string{
+				"0_9.csv": "1,a\n2,b\n",
+			},
+		},
+		{
+			name: "Should sort and deduplicate rows",
+			files: map[string]string{
+				"0_9.csv": "3,c\n1,a\n3,d\n2,b\n",
+			},
+			want: &RepairReport{Rewritten: []string{"0_9.csv"}, Duplicates: 1},
+			wantFiles: map[string]string{
+				"0_9.csv": "1,a\n2,b\n3,d\n",
+			},
+		},
+		{
+			name: "Should keep the last duplicate regardless of the conflict policy",
+			opts: []Option{WithConflictPolicy(ErrorOnConflict)},
+			files: map[string]string{
+				"0_9.csv": "3,c\n1,a\n3,d\n",
+			},
+			want: &RepairReport{Rewritten: []string{"0_9.csv"}, Duplicates: 1},
+			wantFiles: map[string]string{
+				"0_9.csv": "1,a\n3,d\n",
+			},
+		},
+		{
+			name: "Should move rows to the partition of their timestamp",
+			files: map[string]string{
+				"0_9.csv":   "1,a\n12,c\n",
+				"10_19.csv": "11,b\n",
+			},
+			want: &RepairReport{Rewritten: []string{"0_9.csv", "10_19.csv"}, Moved: 1},
+			wantFiles: map[string]string{
+				"0_9.csv":   "1,a\n",
+				"10_19.csv": "11,b\n12,c\n",
+			},
+		},
+		{
+			name: "Should remove partitions without rows left",
+			files: map[string]string{
+				"0_9.csv": "12,c\n",
+			},
+			want: &RepairReport{Rewritten: []string{"10_19.csv"}, Removed: []string{"0_9.csv"}, Moved: 1},
+			wantFiles: map[string]string{
+				"10_19.csv": "12,c\n",
+			},
+		},
+		{
+			name: "Should quarantine lines that can't be parsed",
+			files: map[string]string{
+				"0_9.csv": "1,a\ninvalid,b\n2,b,c\n3,\"c\n4,",
+			},
+			want: &RepairReport{
+				Rewritten: []string{"0_9.csv"},
+				Quarantined: []QuarantinedLine{
+					{Path: "0_9.csv", Line: 2, Content: "invalid,b\n"},
+					{Path: "0_9.csv", Line: 3, Content: "2,b,c\n", Err: csv.ErrFieldCount},
+					{Path: "0_9.csv", Line: 4, Content: "3,\"c\n4,", Err: io.ErrUnexpectedEOF},
+				},
+			},
+			wantFiles: map[string]string{
+				"0_9.csv": "1,a\n",
+			},
+			wantQuarantine: map[string]string{
+				"0_9.csv" + quarantineExtension: "2,\"invalid,b\n\",\"{path}:2: invalid timestamp \"\"invalid\"\" in column 0: strconv.ParseUint: parsing \"\"invalid\"\": invalid syntax\"\n" +
+					"3,\"2,b,c\n\",wrong number of fields\n" +
+					"4,\"3,\"\"c\n4,\",unexpected EOF\n",
+			},
+		},
+		{
+			name: "Should merge plain and compressed files of the same partition",
+			opts: []Option{WithCompression(Gzip)},
+			files: map[string]string{
+				"0_9.csv":    "1,a\n",
+				"0_9.csv.gz": "",
+			},
+			want: &RepairReport{Rewritten: []string{"0_9.csv.gz"}, Removed: []string{"0_9.csv"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(filestest.TempDir(t), 10, tt.opts...)
+			for name, content := range tt.files {
+				err := ioutilx.ReaderToFile(strings.NewReader(content), s.path(name))
+				assert.Nil(t, err)
+			}
+
+			got, err := s.Repair()
+
+			assert.Nil(t, err)
+			assert.Equal(t, prefixPaths(s, tt.want.Rewritten), got.Rewritten)
+			assert.Equal(t, prefixPaths(s, tt.want.Removed), got.Removed)
+			assert.Equal(t, tt.want.Moved, got.Moved)
+			assert.Equal(t, tt.want.Duplicates, got.Duplicates)
+			assert.Equal(t, len(tt.want.Quarantined), len(got.Quarantined))
+			for i, want := range tt.want.Quarantined {
+				assert.Equal(t, s.path(want.Path), got.Quarantined[i].Path)
+				assert.Equal(t, want.Line, got.Quarantined[i].Line)
+				assert.Equal(t, want.Content, got.Quarantined[i].Content)
+				assert.NotNil(t, got.Quarantined[i].Err)
+				if want.Err != nil {
+					assert.ErrorIs(t, got.Quarantined[i].Err, want.Err)
+				}
+			}
+			for name, content := range tt.wantFiles {
+				filestest.FileExistsWithContent(t, s.path(name), content)
+			}
+			for _, path := range got.Removed {
+				_, err := os.Stat(path)
+				assert.True(t, os.IsNotExist(err))
+			}
+			for name, content := range tt.wantQuarantine {
+				content = strings.ReplaceAll(content, "{path}", s.path("0_9.csv"))
+				filestest.FileExistsWithContent(t, filepath.Join(s.dir, quarantineDir, name), content)
+			}
+
+			verify, err := s.Verify()
+			assert.Nil(t, err)
+			if len(got.Rewritten) > 0 {
+				assert.True(t, verify.OK())
+			}
+		})
+	}
+}
+
+func TestStore_Repair_ShouldKeepPointsReadable(t *testing.T) {
+	s := NewStore(filestest.TempDir(t), 10, WithCompression(Zstd))
+	err := ioutil.WriteFile(s.path("0_9.csv"), []byte("15,c\n2,b\n1,a\n"), 0644)
+	assert.Nil(t, err)
+
+	_, err = s.Repair()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Point{
+		{Timestamp: 1, Record: []string{"a"}},
+		{Timestamp: 2, Record: []string{"b"}},
+		{Timestamp: 15, Record: []string{"c"}},
+	}, loadAll(t, s, 0, 19))
+}
+
+func prefixPaths(s *Store, names []string) []string {
+	var paths []string
+	for _, name := range names {
+		paths = append(paths, s.path(name))
+	}
+	return paths
+}
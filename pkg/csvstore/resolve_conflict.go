@@ -0,0 +1,17 @@
+package csvstore
+
+// resolveConflict returns the record to use when reading two points with the
+// same timestamp, i.e. a persisted one and a buffered one. Contrary to
+// writes, reads can't fail because of a conflict, so the existing record is
+// returned if the policy raises an error.
+func resolveConflict(policy ConflictPolicy, timestamp uint64, existing []string, incoming []string) []string {
+	if policy == nil {
+		policy = ReplaceOnConflict
+	}
+
+	resolved, err := policy(timestamp, existing, incoming)
+	if err != nil || resolved == nil {
+		return existing
+	}
+	return resolved
+}
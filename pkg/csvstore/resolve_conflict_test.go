@@ -0,0 +1,45 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resolveConflict(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy ConflictPolicy
+		want   []string
+	}{
+		{
+			name:   "Should return incoming record if policy is nil",
+			policy: nil,
+			want:   []string{"some-incoming-value"},
+		},
+		{
+			name:   "Should return existing record if policy keeps it",
+			policy: KeepOnConflict,
+			want:   []string{"some-existing-value"},
+		},
+		{
+			name:   "Should return existing record if policy raises an error",
+			policy: ErrorOnConflict,
+			want:   []string{"some-existing-value"},
+		},
+		{
+			name: "Should return record returned by the policy",
+			policy: func(timestamp uint64, existing []string, incoming []string) ([]string, error) {
+				return append(existing, incoming...), nil
+			},
+			want: []string{"some-existing-value", "some-incoming-value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveConflict(tt.policy, 1, []string{"some-existing-value"}, []string{"some-incoming-value"})
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
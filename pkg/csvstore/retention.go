@@ -0,0 +1,62 @@
+package csvstore
+
+import (
+	"os"
+)
+
+// Expire deletes the partitions older than the retention set with
+// WithRetention, relative to the last point in the store, and returns the
+// paths of the deleted files. It does nothing if the retention is not set.
+func (s *Store) Expire() ([]string, error) {
+	if s.retention == 0 {
+		return nil, nil
+	}
+
+	last, _, err := s.LastPoint()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if last < s.retention {
+		return nil, nil
+	}
+
+	return s.DeleteBefore(last - s.retention)
+}
+
+// DeleteBefore deletes the partitions whose interval ends before the
+// timestamp, and returns the paths of the deleted files
+func (s *Store) DeleteBefore(timestamp uint64) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	names, err := listDatasets(s.dir, s.index.signed)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, name := range names {
+		_, to, _ := parseDatasetName(name, s.index.signed)
+		if to >= timestamp {
+			continue
+		}
+
+		path := s.path(name)
+		err = os.Remove(path)
+		if err != nil {
+			return deleted, err
+		}
+
+		err = os.Remove(metaPath(path))
+		if err != nil && !os.IsNotExist(err) {
+			return deleted, err
+		}
+
+		deleted = append(deleted, path)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,60 @@
+package csvstore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_DeleteBefore(t *testing.T) {
+	s := newCompactTestStore(t)
+	_, err := s.CompactBefore(10)
+	assert.Nil(t, err)
+
+	got, err := s.DeleteBefore(20)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(s.dir, "0_9.col"), filepath.Join(s.dir, "10_19.csv")}, got)
+	assert.NoFileExists(t, filepath.Join(s.dir, "0_9.col"))
+	assert.NoFileExists(t, filepath.Join(s.dir, "10_19.csv"))
+	assert.FileExists(t, filepath.Join(s.dir, "other.json"))
+	assert.Equal(t, []Point{
+		{Timestamp: 21, Record: []string{"some-value-at-21"}},
+		{Timestamp: 35, Record: []string{"some-value-at-35"}},
+	}, loadAll(t, s, 0, 39))
+}
+
+func TestStore_Expire(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			name: "Should do nothing if retention is not set",
+		},
+		{
+			name: "Should delete partitions older than the retention",
+			opts: []Option{WithRetention(15)},
+			want: []string{"0_9.csv", "10_19.csv"},
+		},
+		{
+			name: "Should do nothing if retention is greater than the last point",
+			opts: []Option{WithRetention(100)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newCompactTestStore(t, tt.opts...)
+
+			got, err := s.Expire()
+
+			assert.Nil(t, err)
+			assert.Equal(t, len(tt.want), len(got))
+			for i, name := range tt.want {
+				assert.Equal(t, filepath.Join(s.dir, name), got[i])
+			}
+		})
+	}
+}
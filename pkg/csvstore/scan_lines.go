@@ -0,0 +1,62 @@
+package csvstore
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// scanLines reads the partition file line by line, without parsing it as CSV,
+// and calls the handler with the content of each record, the number of the
+// line it starts at, and whether it is terminated by a new line. The lines of
+// quoted fields containing new lines are joined in a single record. A
+// truncated compressed file is read up to the point it was truncated.
+func scanLines(path string, handler func(line int, content string, terminated bool) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decompressed, err := compressionOf(path).newReader(file)
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+
+	reader := bufio.NewReader(decompressed)
+	var content strings.Builder
+	line, start, quotes := 0, 0, 0
+	for {
+		text, err := reader.ReadString('\n')
+		if len(text) > 0 {
+			line++
+			if content.Len() == 0 {
+				start = line
+			}
+			content.WriteString(text)
+			quotes += strings.Count(text, `"`)
+
+			if quotes%2 == 0 && strings.HasSuffix(text, "\n") {
+				handlerErr := handler(start, content.String(), true)
+				if handlerErr != nil {
+					return handlerErr
+				}
+				content.Reset()
+				quotes = 0
+			}
+		}
+
+		if err != nil {
+			if err != io.EOF && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return err
+			}
+			if content.Len() > 0 {
+				return handler(start, content.String(), false)
+			}
+			return nil
+		}
+	}
+}
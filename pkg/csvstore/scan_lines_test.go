@@ -0,0 +1,76 @@
+package csvstore
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/pasdam/go-io-utilx/pkg/ioutilx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_scanLines(t *testing.T) {
+	type line struct {
+		line       int
+		content    string
+		terminated bool
+	}
+	tests := []struct {
+		name    string
+		content string
+		want    []line
+	}{
+		{
+			name: "Should return nothing if the file is empty",
+		},
+		{
+			name:    "Should return each line",
+			content: "1,a\n2,b\n",
+			want: []line{
+				{line: 1, content: "1,a\n", terminated: true},
+				{line: 2, content: "2,b\n", terminated: true},
+			},
+		},
+		{
+			name:    "Should join the lines of quoted fields",
+			content: "1,\"a\nb\"\n2,b\n",
+			want: []line{
+				{line: 1, content: "1,\"a\nb\"\n", terminated: true},
+				{line: 3, content: "2,b\n", terminated: true},
+			},
+		},
+		{
+			name:    "Should return the last line as not terminated if truncated",
+			content: "1,a\n2,",
+			want: []line{
+				{line: 1, content: "1,a\n", terminated: true},
+				{line: 2, content: "2,", terminated: false},
+			},
+		},
+		{
+			name:    "Should return the last line as not terminated if a quoted field is truncated",
+			content: "1,a\n2,\"b\n",
+			want: []line{
+				{line: 1, content: "1,a\n", terminated: true},
+				{line: 2, content: "2,\"b\n", terminated: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(filestest.TempDir(t), "0_9.csv")
+			err := ioutilx.ReaderToFile(strings.NewReader(tt.content), path)
+			assert.Nil(t, err)
+
+			var got []line
+			err = scanLines(path, func(number int, content string, terminated bool) error {
+				got = append(got, line{line: number, content: content, terminated: terminated})
+				return nil
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package csvstore
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Column describes a column of the records
+type Column struct {
+	// Name is the name of the column
+	Name string
+
+	// Type is the type of the values of the column
+	Type ColumnType
+}
+
+// Schema contains the columns of the records, excluding the timestamp
+type Schema []Column
+
+// Index returns the index of the column with the name, -1 if there is none
+func (s Schema) Index(name string) int {
+	for i, column := range s {
+		if column.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// resolve returns the index and the type of the column, specified either by
+// name or by index; the type is AutoColumn if not in the schema
+func (s Schema) resolve(column string) (int, ColumnType, error) {
+	index := s.Index(column)
+	if index >= 0 {
+		return index, s[index].Type, nil
+	}
+
+	index, err := strconv.Atoi(column)
+	if err != nil || index < 0 {
+		return 0, AutoColumn, fmt.Errorf("%w: %s", ErrUnknownColumn, column)
+	}
+	return index, AutoColumn, nil
+}
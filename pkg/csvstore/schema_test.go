@@ -0,0 +1,53 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema_Index(t *testing.T) {
+	s := Schema{{Name: "a"}, {Name: "b", Type: IntColumn}}
+
+	assert.Equal(t, 1, s.Index("b"))
+	assert.Equal(t, -1, s.Index("c"))
+}
+
+func TestSchema_resolve(t *testing.T) {
+	s := Schema{{Name: "a"}, {Name: "b", Type: IntColumn}}
+	tests := []struct {
+		name      string
+		column    string
+		wantIndex int
+		wantType  ColumnType
+		wantErr   error
+	}{
+		{
+			name:      "Should resolve column by name",
+			column:    "b",
+			wantIndex: 1,
+			wantType:  IntColumn,
+		},
+		{
+			name:      "Should resolve column by index",
+			column:    "3",
+			wantIndex: 3,
+			wantType:  AutoColumn,
+		},
+		{
+			name:    "Should return error if column is unknown",
+			column:  "c",
+			wantErr: ErrUnknownColumn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIndex, gotType, err := s.resolve(tt.column)
+
+			assert.True(t, errors.Is(err, tt.wantErr), err)
+			assert.Equal(t, tt.wantIndex, gotIndex)
+			assert.Equal(t, tt.wantType, gotType)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package csvstore
+
+// signBit is the bit flipped to map signed timestamps to unsigned ones
+const signBit = uint64(1) << 63
+
+// FromSigned returns the internal representation of a signed timestamp, used
+// by the stores with signed timestamps: the mapping preserves the order, i.e.
+// negative timestamps are before the positive ones
+func FromSigned(timestamp int64) uint64 {
+	return uint64(timestamp) ^ signBit
+}
+
+// ToSigned returns the signed timestamp of its internal representation
+func ToSigned(timestamp uint64) int64 {
+	return int64(timestamp ^ signBit)
+}
+
+// toTimestamp returns the internal representation of the value, either signed
+// or not, failing if it is negative and the timestamps are not signed
+func toTimestamp(value int64, signed bool) (uint64, error) {
+	if signed {
+		return FromSigned(value), nil
+	}
+	if value < 0 {
+		return 0, ErrBeforeEpoch
+	}
+	return uint64(value), nil
+}
+
+// fromTimestamp returns the value of the internal representation of the
+// timestamp, either signed or not
+func fromTimestamp(timestamp uint64, signed bool) int64 {
+	if signed {
+		return ToSigned(timestamp)
+	}
+	return int64(timestamp)
+}
@@ -0,0 +1,41 @@
+package csvstore
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSigned(t *testing.T) {
+	assert.Equal(t, uint64(0), FromSigned(math.MinInt64))
+	assert.Equal(t, signBit-1, FromSigned(-1))
+	assert.Equal(t, signBit, FromSigned(0))
+	assert.Equal(t, uint64(math.MaxUint64), FromSigned(math.MaxInt64))
+	assert.Less(t, FromSigned(-100), FromSigned(-10))
+	assert.Less(t, FromSigned(-10), FromSigned(10))
+}
+
+func TestToSigned(t *testing.T) {
+	for _, value := range []int64{math.MinInt64, -10, 0, 10, math.MaxInt64} {
+		assert.Equal(t, value, ToSigned(FromSigned(value)))
+	}
+}
+
+func Test_toTimestamp(t *testing.T) {
+	got, err := toTimestamp(10, false)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), got)
+
+	_, err = toTimestamp(-10, false)
+	assert.Equal(t, ErrBeforeEpoch, err)
+
+	got, err = toTimestamp(-10, true)
+	assert.Nil(t, err)
+	assert.Equal(t, FromSigned(-10), got)
+}
+
+func Test_fromTimestamp(t *testing.T) {
+	assert.Equal(t, int64(10), fromTimestamp(10, false))
+	assert.Equal(t, int64(-10), fromTimestamp(FromSigned(-10), true))
+}
@@ -0,0 +1,119 @@
+package csvstore
+
+// slotTracker assigns the points, in timestamp order, to the slots of cadence
+// size starting at from, keeping track of the missing and over dense slots
+type slotTracker struct {
+	from      uint64
+	to        uint64
+	cadence   uint64
+	started   bool
+	current   uint64
+	count     int
+	gaps      [][2]uint64
+	anomalies []DensityAnomaly
+}
+
+// slots returns the number of slots in the range
+func (t *slotTracker) slots() uint64 {
+	return (t.to-t.from)/t.cadence + 1
+}
+
+// slot returns the index of the slot of the timestamp
+func (t *slotTracker) slot(timestamp uint64) uint64 {
+	return (timestamp - t.from) / t.cadence
+}
+
+// add assigns a point to its slot
+func (t *slotTracker) add(timestamp uint64) {
+	t.addRun(timestamp, 1)
+}
+
+// addRun assigns the points starting from the timestamp, one for each
+// consecutive slot
+func (t *slotTracker) addRun(first uint64, points int) {
+	k := t.slot(first)
+	if t.started && k <= t.current {
+		if k < t.current {
+			// out of order, the slot has already been counted
+			return
+		}
+		t.count++
+		points--
+		k++
+		if points == 0 {
+			return
+		}
+	}
+
+	t.advance(k)
+	t.current = k + uint64(points) - 1
+	t.count = 1
+}
+
+// finish records the missing slots after the last point
+func (t *slotTracker) finish() {
+	t.advance(t.slots())
+}
+
+// advance closes the current slot, and records the missing ones before k
+func (t *slotTracker) advance(k uint64) {
+	next := uint64(0)
+	if t.started {
+		if t.count > 1 {
+			t.addAnomaly(t.current)
+		}
+		next = t.current + 1
+	}
+	if k > next {
+		t.gaps = append(t.gaps, [2]uint64{next, k - 1})
+	}
+	t.started = true
+}
+
+func (t *slotTracker) addAnomaly(k uint64) {
+	from, to := t.slotInterval(k)
+	if last := len(t.anomalies) - 1; last >= 0 && t.anomalies[last].To+1 == from {
+		t.anomalies[last].To = to
+		t.anomalies[last].Points += t.count
+		return
+	}
+	t.anomalies = append(t.anomalies, DensityAnomaly{From: from, To: to, Points: t.count})
+}
+
+// slotInterval returns the timestamps of the slot, limited to the range
+func (t *slotTracker) slotInterval(k uint64) (uint64, uint64) {
+	from := t.from + k*t.cadence
+	if t.to-from < t.cadence-1 {
+		return from, t.to
+	}
+	return from, from + t.cadence - 1
+}
+
+// missing returns the number of missing slots between first and last
+func (t *slotTracker) missing(first uint64, last uint64) uint64 {
+	var count uint64
+	for _, gap := range t.gaps {
+		from, to := gap[0], gap[1]
+		if from < first {
+			from = first
+		}
+		if to > last {
+			to = last
+		}
+		if from <= to {
+			count += to - from + 1
+		}
+	}
+	return count
+}
+
+// gapIntervals returns the missing slots as intervals of timestamps
+func (t *slotTracker) gapIntervals() []Gap {
+	var gaps []Gap
+	for _, gap := range t.gaps {
+		from, _ := t.slotInterval(gap[0])
+		_, to := t.slotInterval(gap[1])
+		gaps = append(gaps, Gap{From: from, To: to})
+	}
+	return gaps
+}
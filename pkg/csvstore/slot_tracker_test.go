@@ -0,0 +1,77 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_slotTracker(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          uint64
+		to            uint64
+		cadence       uint64
+		points        []uint64
+		wantGaps      []Gap
+		wantAnomalies []DensityAnomaly
+	}{
+		{
+			name:     "Should report the whole range if there are no points",
+			from:     5,
+			to:       14,
+			cadence:  4,
+			wantGaps: []Gap{{From: 5, To: 14}},
+		},
+		{
+			name:     "Should limit the last slot to the range",
+			from:     0,
+			to:       10,
+			cadence:  4,
+			points:   []uint64{1, 5},
+			wantGaps: []Gap{{From: 8, To: 10}},
+		},
+		{
+			name:          "Should merge anomalies of consecutive slots",
+			from:          0,
+			to:            11,
+			cadence:       4,
+			points:        []uint64{0, 1, 4, 5, 6, 8},
+			wantAnomalies: []DensityAnomaly{{From: 0, To: 7, Points: 5}},
+		},
+		{
+			name:     "Should ignore points out of order",
+			from:     0,
+			to:       7,
+			cadence:  4,
+			points:   []uint64{4, 0},
+			wantGaps: []Gap{{From: 0, To: 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &slotTracker{from: tt.from, to: tt.to, cadence: tt.cadence}
+
+			for _, p := range tt.points {
+				tracker.add(p)
+			}
+			tracker.finish()
+
+			assert.Equal(t, tt.wantGaps, tracker.gapIntervals())
+			assert.Equal(t, tt.wantAnomalies, tracker.anomalies)
+		})
+	}
+}
+
+func Test_slotTracker_addRun(t *testing.T) {
+	tracker := &slotTracker{from: 0, to: 19, cadence: 2}
+	tracker.add(0)
+
+	tracker.addRun(1, 3)
+	tracker.addRun(10, 2)
+	tracker.finish()
+
+	assert.Equal(t, []Gap{{From: 6, To: 9}, {From: 14, To: 19}}, tracker.gapIntervals())
+	assert.Equal(t, []DensityAnomaly{{From: 0, To: 1, Points: 2}}, tracker.anomalies)
+	assert.Equal(t, uint64(2), tracker.missing(0, 4))
+}
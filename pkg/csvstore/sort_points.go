@@ -0,0 +1,24 @@
+package csvstore
+
+import (
+	"sort"
+)
+
+// sortPoints sorts the time series and returns its points, using the policy
+// to resolve the ones with the same timestamp; among them, the ones coming
+// later in the series are considered the incoming ones
+func sortPoints(points TimeSeries, policy ConflictPolicy) (dataPointList, InsertStats, error) {
+	sort.Stable(points)
+
+	var stats InsertStats
+	result := make(dataPointList, 0, points.Len())
+	for i := 0; i < points.Len(); i++ {
+		pointStats, err := insert(points.TimestampAtIndex(i), points.CsvAtIndex(i), &result, policy)
+		if err != nil {
+			return nil, InsertStats{}, err
+		}
+		stats.add(pointStats)
+	}
+
+	return result, stats, nil
+}
@@ -0,0 +1,69 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sortPoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		points    *mockTimeSeries
+		policy    ConflictPolicy
+		want      dataPointList
+		wantStats InsertStats
+		wantErr   error
+	}{
+		{
+			name: "Should sort points",
+			points: &mockTimeSeries{
+				points: []*dataPoint{
+					{timestamp: 2, record: []string{"some-value-at-2"}},
+					{timestamp: 0, record: []string{"some-value-at-0"}},
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+			},
+			want: dataPointList{
+				{timestamp: 0, record: []string{"some-value-at-0"}},
+				{timestamp: 1, record: []string{"some-value-at-1"}},
+				{timestamp: 2, record: []string{"some-value-at-2"}},
+			},
+			wantStats: InsertStats{Inserted: 3},
+		},
+		{
+			name: "Should resolve points with the same timestamp",
+			points: &mockTimeSeries{
+				points: []*dataPoint{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+			},
+			policy: KeepOnConflict,
+			want: dataPointList{
+				{timestamp: 1, record: []string{"some-value-at-1"}},
+			},
+			wantStats: InsertStats{Inserted: 1, Skipped: 1},
+		},
+		{
+			name: "Should return error if policy raises it",
+			points: &mockTimeSeries{
+				points: []*dataPoint{
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+					{timestamp: 1, record: []string{"some-value-at-1"}},
+				},
+			},
+			policy:  ErrorOnConflict,
+			wantErr: ErrConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, stats, err := sortPoints(tt.points, tt.policy)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantStats, stats)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package csvstore
+
+import "time"
+
+// Statement is a parsed statement of the query language, e.g.
+//
+//	SELECT mean(price), max(qty) FROM trades
+//	WHERE time >= '2021-01-01T00:00:00Z' AND symbol = 'X'
+//	GROUP BY time(1h) LIMIT 100
+type Statement struct {
+	// Series is the name of the series queried
+	Series string
+
+	// Columns are the names of the columns selected, nil for all of them
+	Columns []string
+
+	// Aggregates are the aggregations selected, if any
+	Aggregates []Aggregate
+
+	// Where filters the points by the values of their columns, if not nil
+	Where Predicate
+
+	// Limit is the maximum number of rows returned, not limited if 0
+	Limit int
+
+	bounds    []*timeBound
+	step      time.Duration
+	stepUnits uint64
+}
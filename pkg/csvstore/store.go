@@ -4,42 +4,100 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Store represent the db, and allows to load datapoints from CSV files
 type Store struct {
-	dir   string
-	index index
+	dir         string
+	index       index
+	policy      ConflictPolicy
+	compression Compression
+	coldAge     uint64
+	retention   uint64
+	strict      bool
+	malformed   MalformedRows
+	dialect     Dialect
+	timestamps  TimestampFormat
+	unit        TimeUnit
+	schema      Schema
+	buffer      *writeBuffer
+	mutex       sync.Mutex
 }
 
 // NewStore creates a new instance of a Store that saves/loads CSV to/from the
 // specified folder, the whole dataset will be split into subset of interval
 // size
-func NewStore(dir string, interval uint64) *Store {
-	return &Store{
+func NewStore(dir string, interval uint64, opts ...Option) *Store {
+	s := newStore(dir, interval, opts...)
+	s.start()
+	return s
+}
+
+func newStore(dir string, interval uint64, opts ...Option) *Store {
+	s := &Store{
 		dir: dir,
 		index: index{
 			interval: interval,
 		},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// start starts the periodic flush of the write buffer, if enabled
+func (s *Store) start() {
+	if s.buffer != nil && s.buffer.interval > 0 {
+		go s.buffer.run(func() error {
+			_, err := s.Flush()
+			return err
+		})
+	}
 }
 
 // LastPoint returns the last data point in the store
 func (s *Store) LastPoint() (timestamp uint64, record []string, err error) {
-	name, err := latestDataset(s.dir)
+	var buffered *dataPoint
+	if s.buffer != nil {
+		buffered = s.buffer.last(s.policy)
+	}
+
+	name, err := latestDataset(s.dir, s.index.signed)
 	if err != nil {
+		if buffered != nil && os.IsNotExist(err) {
+			return buffered.timestamp, buffered.record, nil
+		}
 		return 0, nil, err
 	}
 
 	var points []*dataPoint
-	handler := newTimestampHandler(newRecordsCollector(&points))
+	if len(name) > 0 {
+		err = s.readPartition(s.path(name), s.readOptions(&ReadReport{}), newRecordsCollector(&points))
+		if err != nil {
+			return 0, nil, err
+		}
+	}
 
-	err = readRecords(s.path(name), handler)
-	if err != nil || len(points) == 0 {
-		return 0, nil, err
+	if len(points) == 0 {
+		if buffered != nil {
+			return buffered.timestamp, buffered.record, nil
+		}
+		return 0, nil, nil
 	}
 
 	last := points[len(points)-1]
+	if buffered != nil {
+		if buffered.timestamp > last.timestamp {
+			return buffered.timestamp, buffered.record, nil
+		}
+		if buffered.timestamp == last.timestamp {
+			return last.timestamp, resolveConflict(s.policy, last.timestamp, last.record, buffered.record), nil
+		}
+	}
 
 	return last.timestamp, last.record, nil
 }
@@ -48,98 +106,424 @@ func (s *Store) LastPoint() (timestamp uint64, record []string, err error) {
 // The parameter pointHandler is called for each record, and will receive the
 // its timestamp and the remaining columns as string.
 func (s *Store) LoadPoints(from uint64, to uint64, pointHandler func(uint64, []string) error) error {
-	handler := newTimestampHandler(newFilterRecordsHandler(from, to, pointHandler))
+	_, err := s.LoadPointsWithReport(from, to, pointHandler)
+	return err
+}
+
+// LoadPointsWithReport is like LoadPoints, but it also returns the report of
+// the read, containing the rows skipped since malformed if the store is
+// configured with CollectMalformed
+func (s *Store) LoadPointsWithReport(from uint64, to uint64, pointHandler func(uint64, []string) error) (*ReadReport, error) {
+	return s.load(from, to, nil, nil, pointHandler)
+}
+
+// load loads the data points between from and to matching the filter, if not
+// nil, keeping only the columns specified, or all of them if nil
+func (s *Store) load(from uint64, to uint64, columns []int, filter rowFilter, pointHandler func(uint64, []string) error) (*ReadReport, error) {
+	report := &ReadReport{}
+	opts := s.readOptions(report)
+	opts.columns = columns
+
+	if filter != nil {
+		match := filter
+		if columns != nil {
+			// the columns used by the filter are read too, and removed after
+			// matching the records
+			var positions map[int]int
+			opts.columns, positions = filterColumns(columns, filter)
+			match = filter.remap(positions)
+			pointHandler = newTrimRecordsHandler(len(columns), pointHandler)
+		}
+		pointHandler = newPredicateRecordsHandler(match, pointHandler)
+	}
+
+	drain := func() error { return nil }
+	if s.buffer != nil {
+		buffered := s.buffer.snapshot(from, to, s.policy)
+		if columns := opts.columns; columns != nil {
+			for i, p := range buffered {
+				buffered[i] = &dataPoint{timestamp: p.timestamp, record: project(p.record, columns)}
+			}
+		}
+		pointHandler, drain = newBufferedRecordsHandler(buffered, s.policy, pointHandler)
+	}
+
+	handler := newFilterRecordsHandler(from, to, pointHandler)
 
 	for _, name := range s.index.findDatasets(from, to) {
-		err := readRecords(s.path(name), handler)
+		path, err := s.locate(s.path(name))
+		if err == nil && filter != nil {
+			var pruned bool
+			pruned, err = s.prune(path, filter)
+			if pruned {
+				report.Pruned++
+				continue
+			}
+		}
+		if err == nil {
+			err = s.readPartition(path, opts, handler)
+		}
 		if err != nil {
 			if os.IsNotExist(err) {
 				continue
 			}
-			return err
+			return report, err
 		}
 	}
 
-	return nil
+	return report, drain()
 }
 
-// StorePoints persists the data points in the timeserie in the store.
+// StorePoints persists the data points in the timeserie in the store, and
+// returns the statistics of the write.
+// If the write buffer is enabled, the points are added to it, failing if the
+// conflict policy rejects them, and the result contains the statistics of the
+// buffered points in Buffered, plus the ones of the partitions written if the
+// buffer has been flushed because full.
 // Note it will sort the series before storing it.
-func (s *Store) StorePoints(points TimeSeries) error {
-	sort.Sort(points)
+func (s *Store) StorePoints(points TimeSeries) (*WriteResult, error) {
+	if s.buffer == nil {
+		return s.StorePointsWithPolicy(points, s.policy)
+	}
 
-	from := points.TimestampAtIndex(0)
-	to := points.TimestampAtIndex(points.Len() - 1)
+	start := time.Now()
 
-	datasets, err := s.readDatasets(from, to)
+	incoming, batchStats, err := sortPoints(points, s.policy)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	s.merge(datasets, points)
-	err = writeDatasets(datasets)
+	stats, full, err := s.bufferPoints(incoming)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	stats.Replaced += batchStats.Replaced
+	stats.Skipped += batchStats.Skipped
 
-	return nil
+	result := &WriteResult{}
+	if full {
+		result, err = s.Flush()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Buffered = stats
+	result.Duration = time.Since(start)
+
+	return result, nil
+}
+
+// Flush persists the points in the write buffer, if enabled. The points are
+// kept in the buffer if the flush fails, except the ones rejected by the
+// conflict policy, that are discarded and reported with a
+// *RejectedPointsError, while the others are persisted.
+func (s *Store) Flush() (*WriteResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.flush()
+}
+
+// Close stops the periodic flush of the write buffer, if enabled, and
+// persists the buffered points
+func (s *Store) Close() error {
+	if s.buffer == nil {
+		return nil
+	}
+
+	err := s.buffer.close()
+
+	_, flushErr := s.Flush()
+	if flushErr != nil {
+		return flushErr
+	}
+
+	return err
 }
 
-func (s *Store) merge(datasets map[uint64]*dataset, points TimeSeries) {
+// StorePointsWithPolicy persists the data points in the timeserie in the
+// store, using the specified policy to resolve the ones with the same timestamp
+// of existing points, and returns the statistics of the write.
+// If the write buffer is enabled, it is flushed before writing the points.
+// If all the points of a partition are after the ones already persisted in
+// it, they are appended to its file, otherwise the file is rewritten.
+// Note it will sort the series before storing it.
+func (s *Store) StorePointsWithPolicy(points TimeSeries, policy ConflictPolicy) (*WriteResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the buffered points are older than the incoming ones
+	_, err := s.flush()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.write(points, policy)
+}
+
+func (s *Store) flush() (*WriteResult, error) {
+	if s.buffer == nil {
+		return &WriteResult{}, nil
+	}
+
+	points := s.buffer.startFlush()
+	if len(points) == 0 {
+		s.buffer.endFlush(false, s.policy)
+		return &WriteResult{}, nil
+	}
+
+	// the points rejected by the policy are discarded, keeping the persisted
+	// ones, otherwise they would make every later flush fail
+	storePolicy := s.policy
+	if storePolicy == nil {
+		storePolicy = ReplaceOnConflict
+	}
+	var rejected *RejectedPointsError
+	policy := func(timestamp uint64, existing []string, incoming []string) ([]string, error) {
+		resolved, err := storePolicy(timestamp, existing, incoming)
+		if err != nil {
+			if rejected == nil {
+				rejected = &RejectedPointsError{Err: err}
+			}
+			rejected.Timestamps = append(rejected.Timestamps, timestamp)
+			return nil, nil
+		}
+		return resolved, nil
+	}
+
+	result, err := s.write(points, policy)
+	s.buffer.endFlush(err != nil, s.policy)
+	if err == nil && rejected != nil {
+		return result, rejected
+	}
+
+	return result, err
+}
+
+func (s *Store) write(points TimeSeries, policy ConflictPolicy) (*WriteResult, error) {
+	start := time.Now()
+
+	err := s.validateFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Stable(points)
+
+	datasets := make(map[uint64]*dataset)
+	_, err = s.merge(datasets, points, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ds := range datasets {
+		err = s.mergeExisting(ds, policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	partitions, err := writeDatasets(datasets)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WriteResult{
+		Partitions: partitions,
+	}
+	for _, p := range partitions {
+		result.InsertStats.add(p.InsertStats)
+		result.Bytes += p.Bytes
+	}
+	result.Duration = time.Since(start)
+
+	return result, nil
+}
+
+func (s *Store) merge(datasets map[uint64]*dataset, points TimeSeries, policy ConflictPolicy) (InsertStats, error) {
+	var stats InsertStats
 	for i := 0; i < points.Len(); i++ {
 		timestamp := points.TimestampAtIndex(i)
-		from := (timestamp / s.index.interval) * s.index.interval
+		from, to := timestampToInterval(timestamp, s.index.interval, s.index.signed)
 
 		d := datasets[from]
 		if d == nil {
 			d = &dataset{
-				path: s.path(datasetName(from, from+s.index.interval-1)),
+				path:   s.path(datasetName(from, to, s.index.signed)) + s.compression.suffix(),
+				format: s.fileFormat(),
 			}
 			datasets[from] = d
 		}
 
-		insert(timestamp, points.CsvAtIndex(i), &d.points)
+		pointStats, err := insert(timestamp, points.CsvAtIndex(i), &d.points, policy)
+		if err != nil {
+			return InsertStats{}, err
+		}
+		d.stats.add(pointStats)
+		stats.add(pointStats)
 	}
+
+	return stats, nil
 }
 
 func (s *Store) path(datasetName string) string {
 	return filepath.Join(s.dir, datasetName)
 }
 
-func (s *Store) readDatasets(from uint64, to uint64) (map[uint64]*dataset, error) {
-	datasetNames := s.index.findDatasets(from, to)
-	datasets := make(map[uint64]*dataset)
+// canAppend returns true if the timestamp is after the one of the last point
+// persisted in the file, so that the points can be appended to it
+func (s *Store) canAppend(path string, timestamp uint64) (bool, error) {
+	if isColumnar(path) {
+		return false, nil
+	}
+
+	record, ok, err := readLastRecord(path, s.dialect)
+	if err != nil || !ok {
+		return false, err
+	}
+	if record == nil {
+		return true, nil
+	}
+
+	var last uint64
+	handler := newTimestampHandler(s.fileFormat().timestamps, func(timestamp uint64, _ []string) error {
+		last = timestamp
+		return nil
+	})
+	if handler(record) != nil {
+		return false, nil
+	}
+
+	return last < timestamp, nil
+}
+
+// locate returns the path of the existing partition file, trying the
+// extensions of all the supported compressions, starting from the one of the
+// store, and then the columnar format
+func (s *Store) locate(path string) (string, error) {
+	var firstErr error
+	candidates := make([]string, 0, len(compressions)+2)
+	candidates = append(candidates, path+s.compression.suffix())
+	for _, c := range compressions {
+		candidates = append(candidates, path+c.suffix())
+	}
+	candidates = append(candidates, strings.TrimSuffix(path, filepath.Ext(path))+columnarExtension)
+
+	for _, candidate := range candidates {
+		_, err := os.Stat(candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return "", firstErr
+}
 
+// mergeExisting merges the points of the dataset with the ones persisted in
+// its file, if any, or marks the dataset to be appended to the file. If the
+// existing file has a different compression, it is replaced by the new one.
+func (s *Store) mergeExisting(ds *dataset, policy ConflictPolicy) error {
+	existing, err := s.locate(strings.TrimSuffix(ds.path, s.compression.suffix()))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	appendable, err := s.canAppend(existing, ds.points[0].timestamp)
+	if err != nil {
+		return err
+	}
+	if appendable {
+		ds.path = existing
+		ds.append = true
+		return nil
+	}
+
+	points, err := s.readDataset(existing)
+	if err != nil {
+		return err
+	}
+
+	merged, stats, err := mergePoints(points, ds.points, policy)
+	if err != nil {
+		return err
+	}
+
+	// the points have been counted as inserted when grouped by dataset
+	stats.Replaced += ds.stats.Replaced
+	stats.Skipped += ds.stats.Skipped
+
+	ds.points = merged
+	ds.stats = stats
+	ds.replaces = existing
+
+	return nil
+}
+
+func (s *Store) readDataset(path string) (dataPointList, error) {
 	maxSize := s.index.interval
 	if maxSize > 10000 {
 		maxSize = 10000
 	}
 
-	for i := 0; i < len(datasetNames); i++ {
-		from, _, _ := parseDatasetName(datasetNames[i])
+	points := make([]*dataPoint, 0, maxSize)
+
+	err := s.readPartition(path, readOptions{format: s.fileFormat()}, newRecordsCollector(&points))
+	if err != nil {
+		return nil, err
+	}
 
-		path := s.path(datasetNames[i])
+	return points, nil
+}
 
-		_, err := os.Stat(path)
-		if err != nil {
-			continue
+// readOptions returns the options to read the partitions, according to the
+// configuration of the store, collecting the malformed rows in the report
+func (s *Store) readOptions(report *ReadReport) readOptions {
+	switch s.malformed {
+	case SkipMalformed:
+		return readOptions{
+			malformed: func(*PartitionError) error { return nil },
+			format:    s.fileFormat(),
 		}
-
-		points := make([]*dataPoint, 0, maxSize)
-		d := &dataset{
-			path: path,
+	case CollectMalformed:
+		return readOptions{
+			malformed: func(err *PartitionError) error {
+				report.Skipped = append(report.Skipped, err)
+				return nil
+			},
+			format: s.fileFormat(),
 		}
+	}
+	return readOptions{format: s.fileFormat()}
+}
 
-		handler := newTimestampHandler(newRecordsCollector(&points))
-
-		err = readRecords(d.path, handler)
+// readPartition reads the partition file, either CSV or columnar, calling the
+// handler for each data point, and validating them if strict reads are enabled
+func (s *Store) readPartition(path string, opts readOptions, handler func(uint64, []string) error) error {
+	if s.strict {
+		from, to, err := parseDatasetName(filepath.Base(path), s.index.signed)
 		if err != nil {
-			return nil, err
+			return err
 		}
-
-		d.points = points
-		datasets[from] = d
+		handler = newStrictRecordsHandler(from, to, s.fileFormat().timestamps, handler)
 	}
+	return readPartitionFile(path, opts, handler)
+}
 
-	return datasets, nil
+// readPartitionFile reads the partition file, either CSV or columnar, calling
+// the handler for each data point
+func readPartitionFile(path string, opts readOptions, handler func(uint64, []string) error) error {
+	if isColumnar(path) {
+		err := readColumnar(path, opts, handler)
+		if err == ErrInvalidColumnarFile {
+			return &PartitionError{Path: path, Column: -1, Kind: ErrCorruptPartition, Err: err}
+		}
+		return err
+	}
+	return readRecords(path, opts, newTimestampHandler(opts.format.timestamps, newProjectionHandler(opts.columns, handler)))
 }
@@ -0,0 +1,133 @@
+package csvstore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrInvalidAggregate is returned when an aggregation can't be applied to a
+// column
+var ErrInvalidAggregate = errors.New("invalid aggregate")
+
+// Aggregate computes the aggregates of the points selected by the query, for
+// each time bucket with at least one point, calling the handler with the start
+// of the bucket, or From if Step is 0, and the values of the aggregates, in
+// the same order, NaN if undefined. If there is no predicate and all the
+// aggregates are Count, Min or Max, the partitions entirely within a bucket
+// and the range are aggregated using their statistics, without reading them.
+func (s *Store) Aggregate(q AggregateQuery, handler func(uint64, []float64) error) (*ReadReport, error) {
+	b := &bucketAggregator{
+		from:       q.From,
+		step:       q.Step,
+		signed:     s.index.signed,
+		aggregates: q.Aggregates,
+		positions:  make([]int, len(q.Aggregates)),
+		columns:    make([]int, len(q.Aggregates)),
+		handler:    handler,
+	}
+
+	read := []int{}
+	summarize := q.Where == nil
+	for i, a := range q.Aggregates {
+		summarize = summarize && a.Func.fromStats()
+		if a.Column == AllRows {
+			if a.Func != Count {
+				return nil, fmt.Errorf("%w: %v(%s)", ErrInvalidAggregate, a.Func, a.Column)
+			}
+			b.positions[i], b.columns[i] = -1, -1
+			continue
+		}
+
+		column, _, err := s.schema.resolve(a.Column)
+		if err != nil {
+			return nil, err
+		}
+		b.columns[i] = column
+		b.positions[i] = len(read)
+		read = append(read, column)
+	}
+
+	var filter rowFilter
+	if q.Where != nil {
+		var err error
+		filter, err = q.Where.bind(s.schema)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := &ReadReport{}
+	if q.To < q.From {
+		return report, nil
+	}
+
+	partitionFrom, _ := timestampToInterval(q.From, s.index.interval, s.index.signed)
+	for {
+		_, partitionTo := timestampToInterval(partitionFrom, s.index.interval, s.index.signed)
+
+		var meta *partitionMeta
+		if summarize && q.From <= partitionFrom && partitionTo <= q.To && b.bucket(partitionFrom) == b.bucket(partitionTo) {
+			var err error
+			meta, err = s.summary(datasetName(partitionFrom, partitionTo, s.index.signed), partitionFrom, partitionTo)
+			if err != nil {
+				return report, err
+			}
+		}
+
+		if meta != nil {
+			if len(meta.File) > 0 {
+				report.Summarized++
+			}
+			err := b.addStats(partitionFrom, meta)
+			if err != nil {
+				return report, err
+			}
+		} else {
+			from, to := partitionFrom, partitionTo
+			if from < q.From {
+				from = q.From
+			}
+			if to > q.To {
+				to = q.To
+			}
+			partitionReport, err := s.load(from, to, read, filter, b.add)
+			if partitionReport != nil {
+				report.Skipped = append(report.Skipped, partitionReport.Skipped...)
+				report.Pruned += partitionReport.Pruned
+			}
+			if err != nil {
+				return report, err
+			}
+		}
+
+		if partitionTo >= q.To {
+			break
+		}
+		partitionFrom = partitionTo + 1
+	}
+
+	return report, b.flush()
+}
+
+// summary returns the metadata of the partition, with the statistics of all
+// its points, nil if not available or if there are buffered points for it
+func (s *Store) summary(name string, from uint64, to uint64) (*partitionMeta, error) {
+	if s.buffer != nil && len(s.buffer.snapshot(from, to, s.policy)) > 0 {
+		return nil, nil
+	}
+
+	path, err := s.locate(s.path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &partitionMeta{HasStats: true}, nil
+		}
+		return nil, err
+	}
+
+	meta, err := storedPartitionMeta(path)
+	if err != nil || meta == nil || !meta.HasStats {
+		return nil, err
+	}
+	return meta, nil
+}
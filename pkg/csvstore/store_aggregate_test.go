@@ -0,0 +1,198 @@
+package csvstore
+
+import (
+	"errors"
+	"io/ioutil"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+type aggregateRow struct {
+	bucket uint64
+	values []float64
+}
+
+func newAggregateTestStore(t *testing.T, opts ...Option) *Store {
+	opts = append([]Option{WithSchema(Column{Name: "symbol", Type: StringColumn}, Column{Name: "price", Type: FloatColumn})}, opts...)
+	s := NewStore(filestest.TempDir(t), 10, opts...)
+	_, err := s.StorePointsWithPolicy(Points{
+		{Timestamp: 1, Record: []string{"X", "50"}},
+		{Timestamp: 2, Record: []string{"Y", "150"}},
+		{Timestamp: 11, Record: []string{"X", "20"}},
+		{Timestamp: 12, Record: []string{"X", ""}},
+		{Timestamp: 21, Record: []string{"Y", "200"}},
+		{Timestamp: 35, Record: []string{"Y", "n/a"}},
+	}, ReplaceOnConflict)
+	assert.Nil(t, err)
+	return s
+}
+
+func TestStore_Aggregate(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          AggregateQuery
+		want           []aggregateRow
+		wantSummarized int
+	}{
+		{
+			name: "Should aggregate the whole range from the statistics",
+			query: AggregateQuery{
+				From:       0,
+				To:         39,
+				Aggregates: []Aggregate{{Func: Count, Column: AllRows}, {Func: Count, Column: "price"}, {Func: Min, Column: "price"}, {Func: Max, Column: "1"}},
+			},
+			want:           []aggregateRow{{0, []float64{6, 5, 20, 200}}},
+			wantSummarized: 4,
+		},
+		{
+			name: "Should read partitions not entirely in the range",
+			query: AggregateQuery{
+				From:       2,
+				To:         29,
+				Aggregates: []Aggregate{{Func: Count, Column: AllRows}, {Func: Min, Column: "price"}},
+			},
+			want:           []aggregateRow{{2, []float64{4, 20}}},
+			wantSummarized: 2,
+		},
+		{
+			name: "Should read partitions for aggregations not available in the statistics",
+			query: AggregateQuery{
+				From:       0,
+				To:         39,
+				Aggregates: []Aggregate{{Func: Sum, Column: "price"}, {Func: Mean, Column: "price"}},
+			},
+			want: []aggregateRow{{0, []float64{420, 105}}},
+		},
+		{
+			name: "Should aggregate by time bucket",
+			query: AggregateQuery{
+				From:       0,
+				To:         39,
+				Step:       20,
+				Aggregates: []Aggregate{{Func: Max, Column: "price"}, {Func: Count, Column: "symbol"}},
+			},
+			want: []aggregateRow{
+				{0, []float64{150, 4}},
+				{20, []float64{200, 2}},
+			},
+			wantSummarized: 4,
+		},
+		{
+			name: "Should aggregate buckets smaller than the partitions",
+			query: AggregateQuery{
+				From:       0,
+				To:         39,
+				Step:       5,
+				Aggregates: []Aggregate{{Func: Max, Column: "price"}},
+			},
+			want: []aggregateRow{
+				{0, []float64{150}},
+				{10, []float64{20}},
+				{20, []float64{200}},
+				{35, []float64{math.NaN()}},
+			},
+		},
+		{
+			name: "Should aggregate matching points",
+			query: AggregateQuery{
+				From:       0,
+				To:         39,
+				Where:      Compare("symbol", Equal, "X"),
+				Aggregates: []Aggregate{{Func: Count, Column: AllRows}, {Func: Max, Column: "price"}},
+			},
+			want: []aggregateRow{{0, []float64{3, 50}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAggregateTestStore(t)
+
+			var got []aggregateRow
+			report, err := s.Aggregate(tt.query, func(bucket uint64, values []float64) error {
+				got = append(got, aggregateRow{bucket, values})
+				return nil
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, len(tt.want), len(got))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].bucket, got[i].bucket)
+				assert.Equal(t, len(tt.want[i].values), len(got[i].values))
+				for j, want := range tt.want[i].values {
+					if math.IsNaN(want) {
+						assert.True(t, math.IsNaN(got[i].values[j]))
+					} else {
+						assert.Equal(t, want, got[i].values[j])
+					}
+				}
+			}
+			assert.Equal(t, tt.wantSummarized, report.Summarized)
+		})
+	}
+}
+
+func TestStore_Aggregate_ShouldNotReadSummarizedPartitions(t *testing.T) {
+	s := newAggregateTestStore(t)
+	content, err := ioutil.ReadFile(s.path("0_9.csv"))
+	assert.Nil(t, err)
+	// the partition can't be parsed, but its size is consistent with the metadata
+	err = ioutil.WriteFile(s.path("0_9.csv"), []byte(strings.Repeat("\"", len(content))), 0644)
+	assert.Nil(t, err)
+
+	var got []float64
+	_, err = s.Aggregate(AggregateQuery{From: 0, To: 9, Aggregates: []Aggregate{{Func: Max, Column: "price"}}}, func(bucket uint64, values []float64) error {
+		got = values
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{150}, got)
+}
+
+func TestStore_Aggregate_ShouldIncludeBufferedPoints(t *testing.T) {
+	s := newAggregateTestStore(t, WithWriteBuffer(10, 0))
+	defer s.Close()
+	assert.Nil(t, s.Append(5, []string{"Z", "500"}))
+
+	var got []float64
+	report, err := s.Aggregate(AggregateQuery{From: 0, To: 39, Aggregates: []Aggregate{{Func: Max, Column: "price"}}}, func(bucket uint64, values []float64) error {
+		got = values
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{500}, got)
+	assert.Equal(t, 3, report.Summarized)
+}
+
+func TestStore_Aggregate_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name      string
+		aggregate Aggregate
+		wantErr   error
+	}{
+		{
+			name:      "Should return error if column is unknown",
+			aggregate: Aggregate{Func: Max, Column: "missing"},
+			wantErr:   ErrUnknownColumn,
+		},
+		{
+			name:      "Should return error if aggregating all rows with other than count",
+			aggregate: Aggregate{Func: Sum, Column: AllRows},
+			wantErr:   ErrInvalidAggregate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAggregateTestStore(t)
+
+			_, err := s.Aggregate(AggregateQuery{From: 0, To: 39, Aggregates: []Aggregate{tt.aggregate}}, func(uint64, []float64) error { return nil })
+
+			assert.True(t, errors.Is(err, tt.wantErr), err)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package csvstore
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// configFile is the name of the file, in the folder of the store, containing
+// its persisted configuration
+const configFile = "csvstore.json"
+
+// ErrConfigMismatch is returned by OpenStore when the options are different
+// from the persisted configuration of the store
+var ErrConfigMismatch = errors.New("options different from the store configuration")
+
+// storeConfig is the configuration persisted with the store, needed to
+// interpret its timestamps, and the labels of the series
+type storeConfig struct {
+	Unit   TimeUnit `json:"unit"`
+	Signed bool     `json:"signed"`
+	Labels Labels   `json:"labels,omitempty"`
+}
+
+func readStoreConfig(dir string) (*storeConfig, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, configFile))
+	if err != nil {
+		return nil, err
+	}
+
+	config := &storeConfig{}
+	err = json.Unmarshal(content, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func writeStoreConfig(dir string, config *storeConfig) error {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, configFile), content, 0644)
+}
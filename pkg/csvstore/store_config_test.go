@@ -0,0 +1,31 @@
+package csvstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeStoreConfig_readStoreConfig(t *testing.T) {
+	dir := filepath.Join(filestest.TempDir(t), "some-store")
+	want := &storeConfig{Unit: Millisecond, Signed: true}
+
+	err := writeStoreConfig(dir, want)
+	assert.Nil(t, err)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, configFile), `{"unit":"ms","signed":true}`)
+
+	got, err := readStoreConfig(dir)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func Test_readStoreConfig_ShouldReturnErrorIfFileDoesNotExist(t *testing.T) {
+	got, err := readStoreConfig(filestest.TempDir(t))
+
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, got)
+}
@@ -0,0 +1,65 @@
+package csvstore
+
+// storeCursor walks the points of a store in a range, loading one partition
+// at a time
+type storeCursor struct {
+	store  *Store
+	index  int
+	from   uint64
+	to     uint64
+	next   uint64
+	done   bool
+	points []*dataPoint
+	pos    int
+}
+
+func newStoreCursor(s *Store, index int, from uint64, to uint64) *storeCursor {
+	next, _ := timestampToInterval(from, s.index.interval, s.index.signed)
+	return &storeCursor{
+		store: s,
+		index: index,
+		from:  from,
+		to:    to,
+		next:  next,
+		pos:   -1,
+	}
+}
+
+// advance moves the cursor to the next point, returning false if there are
+// no more points in the range
+func (c *storeCursor) advance() (bool, error) {
+	c.pos++
+	for c.pos >= len(c.points) {
+		if c.done {
+			return false, nil
+		}
+
+		partitionFrom := c.next
+		_, partitionTo := timestampToInterval(partitionFrom, c.store.index.interval, c.store.index.signed)
+		if partitionTo >= c.to {
+			c.done = true
+		} else {
+			c.next = partitionTo + 1
+		}
+		if partitionFrom < c.from {
+			partitionFrom = c.from
+		}
+		if partitionTo > c.to {
+			partitionTo = c.to
+		}
+
+		c.points = c.points[:0]
+		c.pos = 0
+		err := c.store.LoadPoints(partitionFrom, partitionTo, newRecordsCollector(&c.points))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// point returns the current point of the cursor
+func (c *storeCursor) point() *dataPoint {
+	return c.points[c.pos]
+}
@@ -0,0 +1,24 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_storeCursor_advance(t *testing.T) {
+	s := newCompactTestStore(t)
+	c := newStoreCursor(s, 0, 3, 34)
+
+	var got []uint64
+	for {
+		ok, err := c.advance()
+		assert.Nil(t, err)
+		if !ok {
+			break
+		}
+		got = append(got, c.point().timestamp)
+	}
+
+	assert.Equal(t, []uint64{3, 11, 21}, got)
+}
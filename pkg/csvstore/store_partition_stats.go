@@ -0,0 +1,56 @@
+package csvstore
+
+import (
+	"os"
+)
+
+// PartitionStats returns the statistics of the partitions overlapping the
+// range between from and to, sorted by interval. They are read from the
+// metadata of the partitions, computed when writing them, or computed
+// reading the partitions whose metadata is missing or outdated.
+func (s *Store) PartitionStats(from uint64, to uint64) ([]*PartitionStats, error) {
+	names, err := listDatasets(s.dir, s.index.signed)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var stats []*PartitionStats
+	for _, name := range names {
+		partitionFrom, partitionTo, _ := parseDatasetName(name, s.index.signed)
+		if partitionTo < from || partitionFrom > to {
+			continue
+		}
+
+		path := s.path(name)
+		meta, err := s.partitionStatsMeta(path)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, newPartitionStats(path, partitionFrom, partitionTo, meta))
+	}
+
+	return stats, nil
+}
+
+// partitionStatsMeta returns the metadata of the partition, with the
+// statistics of its columns, computing it if not stored
+func (s *Store) partitionStatsMeta(path string) (*partitionMeta, error) {
+	meta, err := storedPartitionMeta(path)
+	if err != nil || meta != nil && meta.HasStats {
+		return meta, err
+	}
+
+	meta = &partitionMeta{}
+	err = readPartitionFile(path, readOptions{format: s.fileFormat()}, func(timestamp uint64, record []string) error {
+		meta.add(timestamp, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
@@ -0,0 +1,57 @@
+package csvstore
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_PartitionStats(t *testing.T) {
+	s := newAggregateTestStore(t)
+	// the statistics are computed if the metadata is missing
+	err := os.Remove(metaPath(s.path("10_19.csv")))
+	assert.Nil(t, err)
+
+	got, err := s.PartitionStats(5, 25)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*PartitionStats{
+		{
+			Path:  s.path("0_9.csv"),
+			From:  0,
+			To:    9,
+			Rows:  2,
+			First: 1,
+			Last:  2,
+			Columns: []ColumnStats{
+				{Count: 2},
+				{Count: 2, Numeric: true, Min: 50, Max: 150},
+			},
+		},
+		{
+			Path:  s.path("10_19.csv"),
+			From:  10,
+			To:    19,
+			Rows:  2,
+			First: 11,
+			Last:  12,
+			Columns: []ColumnStats{
+				{Count: 2},
+				{Count: 1, Nulls: 1, Numeric: true, Min: 20, Max: 20},
+			},
+		},
+		{
+			Path:  s.path("20_29.csv"),
+			From:  20,
+			To:    29,
+			Rows:  1,
+			First: 21,
+			Last:  21,
+			Columns: []ColumnStats{
+				{Count: 1},
+				{Count: 1, Numeric: true, Min: 200, Max: 200},
+			},
+		},
+	}, got)
+}
@@ -0,0 +1,99 @@
+package csvstore
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// ErrUnknownColumn is returned when a query refers to a column that is not
+// in the schema of the store
+var ErrUnknownColumn = errors.New("unknown column")
+
+// Query is like LoadPointsWithReport for the points selected by the query.
+// The records contain only the columns selected, in the same order, with an
+// empty value if missing in the row; the columnar partitions read only them.
+// The predicate is evaluated while scanning the partitions, skipping the ones
+// whose statistics show that none of their points can match.
+func (s *Store) Query(q Query, handler func(uint64, []string) error) (*ReadReport, error) {
+	columns, err := s.projection(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter rowFilter
+	if q.Where != nil {
+		filter, err = q.Where.bind(s.schema)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.load(q.From, q.To, columns, filter, handler)
+}
+
+// prune returns true if the statistics of the partition show that none of
+// its points matches the filter, and there are no buffered points for it
+func (s *Store) prune(path string, filter rowFilter) (bool, error) {
+	if s.buffer != nil {
+		from, to, err := parseDatasetName(filepath.Base(path), s.index.signed)
+		if err != nil {
+			return false, err
+		}
+		if len(s.buffer.snapshot(from, to, s.policy)) > 0 {
+			return false, nil
+		}
+	}
+
+	meta, err := storedPartitionMeta(path)
+	if err != nil || meta == nil || meta.columns() == nil {
+		return false, err
+	}
+
+	return !filter.mayMatch(meta.columns()), nil
+}
+
+// filterColumns returns the columns to read, i.e. the ones selected followed
+// by the other ones used by the filter, and the positions of the latter in
+// the records read
+func filterColumns(columns []int, filter rowFilter) ([]int, map[int]int) {
+	read := append([]int(nil), columns...)
+	positions := make(map[int]int)
+	for i, column := range columns {
+		if _, ok := positions[column]; !ok {
+			positions[column] = i
+		}
+	}
+	for _, column := range filter.columns() {
+		if _, ok := positions[column]; !ok {
+			positions[column] = len(read)
+			read = append(read, column)
+		}
+	}
+	return read, positions
+}
+
+// projection returns the indexes of the columns selected by the query, nil if
+// all of them are
+func (s *Store) projection(q Query) ([]int, error) {
+	if len(q.Columns) == 0 && len(q.Names) == 0 {
+		return nil, nil
+	}
+
+	columns := make([]int, 0, len(q.Columns)+len(q.Names))
+	for _, column := range q.Columns {
+		if column < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownColumn, column)
+		}
+		columns = append(columns, column)
+	}
+	for _, name := range q.Names {
+		column := s.schema.Index(name)
+		if column < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, name)
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
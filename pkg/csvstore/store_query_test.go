@@ -0,0 +1,183 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Query(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    Query
+		columnar bool
+		buffered bool
+		want     []Point
+		wantErr  error
+	}{
+		{
+			name:  "Should return all the columns",
+			query: Query{From: 0, To: 19},
+			want: []Point{
+				{Timestamp: 1, Record: []string{"a1", "b1", "c1"}},
+				{Timestamp: 12, Record: []string{"a12", "b12"}},
+			},
+		},
+		{
+			name:  "Should return the columns selected by index",
+			query: Query{From: 0, To: 19, Columns: []int{2, 0}},
+			want: []Point{
+				{Timestamp: 1, Record: []string{"c1", "a1"}},
+				{Timestamp: 12, Record: []string{"", "a12"}},
+			},
+		},
+		{
+			name:  "Should return the columns selected by name",
+			query: Query{From: 0, To: 19, Columns: []int{5}, Names: []string{"b"}},
+			want: []Point{
+				{Timestamp: 1, Record: []string{"", "b1"}},
+				{Timestamp: 12, Record: []string{"", "b12"}},
+			},
+		},
+		{
+			name:     "Should return the columns selected from columnar partitions",
+			query:    Query{From: 0, To: 19, Columns: []int{2, 0, 5}},
+			columnar: true,
+			want: []Point{
+				{Timestamp: 1, Record: []string{"c1", "a1", ""}},
+				{Timestamp: 12, Record: []string{"", "a12", ""}},
+			},
+		},
+		{
+			name:     "Should return the columns selected from buffered points",
+			query:    Query{From: 0, To: 19, Names: []string{"c"}},
+			buffered: true,
+			want: []Point{
+				{Timestamp: 1, Record: []string{"c1"}},
+				{Timestamp: 12, Record: []string{""}},
+			},
+		},
+		{
+			name:    "Should return error if column name is unknown",
+			query:   Query{From: 0, To: 19, Names: []string{"d"}},
+			wantErr: ErrUnknownColumn,
+		},
+		{
+			name:    "Should return error if column index is negative",
+			query:   Query{From: 0, To: 19, Columns: []int{-1}},
+			wantErr: ErrUnknownColumn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithSchema(Column{Name: "a"}, Column{Name: "b"}, Column{Name: "c"})}
+			if tt.buffered {
+				opts = append(opts, WithWriteBuffer(10, 0))
+			}
+			s := NewStore(filestest.TempDir(t), 10, opts...)
+			defer s.Close()
+			assert.Nil(t, s.Append(1, []string{"a1", "b1", "c1"}))
+			assert.Nil(t, s.Append(12, []string{"a12", "b12"}))
+			if tt.columnar {
+				_, err := s.CompactBefore(20)
+				assert.Nil(t, err)
+			}
+
+			var got []Point
+			_, err := s.Query(tt.query, func(timestamp uint64, record []string) error {
+				got = append(got, Point{Timestamp: timestamp, Record: record})
+				return nil
+			})
+
+			assert.True(t, errors.Is(err, tt.wantErr), err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStore_Query_ShouldFilterWithPredicate(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      Query
+		columnar   bool
+		buffered   []string
+		want       []Point
+		wantPruned int
+	}{
+		{
+			name:  "Should return matching points skipping partitions",
+			query: Query{From: 0, To: 29, Where: Compare("price", Greater, "100")},
+			want: []Point{
+				{Timestamp: 2, Record: []string{"Y", "150"}},
+				{Timestamp: 21, Record: []string{"Y", "200"}},
+			},
+			wantPruned: 1,
+		},
+		{
+			name:  "Should filter on columns not selected",
+			query: Query{From: 0, To: 29, Names: []string{"symbol"}, Where: And(Compare("price", Less, "100"), Compare("symbol", Equal, "X"))},
+			want: []Point{
+				{Timestamp: 1, Record: []string{"X"}},
+				{Timestamp: 11, Record: []string{"X"}},
+				{Timestamp: 12, Record: []string{"X"}},
+			},
+			wantPruned: 1,
+		},
+		{
+			name:     "Should filter columnar partitions",
+			query:    Query{From: 0, To: 29, Columns: []int{0}, Where: Compare("price", GreaterOrEqual, "200")},
+			columnar: true,
+			want: []Point{
+				{Timestamp: 21, Record: []string{"Y"}},
+			},
+			wantPruned: 2,
+		},
+		{
+			name:     "Should not skip partitions with buffered points",
+			query:    Query{From: 0, To: 29, Where: Compare("price", Greater, "100")},
+			buffered: []string{"Z", "500"},
+			want: []Point{
+				{Timestamp: 2, Record: []string{"Y", "150"}},
+				{Timestamp: 15, Record: []string{"Z", "500"}},
+				{Timestamp: 21, Record: []string{"Y", "200"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithSchema(Column{Name: "symbol", Type: StringColumn}, Column{Name: "price", Type: FloatColumn})}
+			if tt.buffered != nil {
+				opts = append(opts, WithWriteBuffer(10, 0))
+			}
+			s := NewStore(filestest.TempDir(t), 10, opts...)
+			defer s.Close()
+			_, err := s.StorePointsWithPolicy(Points{
+				{Timestamp: 1, Record: []string{"X", "50"}},
+				{Timestamp: 2, Record: []string{"Y", "150"}},
+				{Timestamp: 11, Record: []string{"X", "20"}},
+				{Timestamp: 12, Record: []string{"X", "30"}},
+				{Timestamp: 21, Record: []string{"Y", "200"}},
+			}, ReplaceOnConflict)
+			assert.Nil(t, err)
+			if tt.columnar {
+				_, err := s.CompactBefore(30)
+				assert.Nil(t, err)
+			}
+			if tt.buffered != nil {
+				assert.Nil(t, s.Append(15, tt.buffered))
+			}
+
+			var got []Point
+			report, err := s.Query(tt.query, func(timestamp uint64, record []string) error {
+				got = append(got, Point{Timestamp: timestamp, Record: record})
+				return nil
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPruned, report.Pruned)
+		})
+	}
+}
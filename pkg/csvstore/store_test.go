@@ -1,12 +1,15 @@
 package csvstore
 
 import (
+	"encoding/csv"
 	"errors"
+	"io/ioutil"
 	"math"
-	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pasdam/go-files-test/pkg/filestest"
 	"github.com/pasdam/go-io-utilx/pkg/ioutilx"
@@ -51,10 +54,22 @@ func TestNewStore(t *testing.T) {
 
 			assert.Equal(t, tt.args.dir, got.dir)
 			assert.Equal(t, tt.args.interval, got.index.interval)
+			assert.Nil(t, got.policy)
 		})
 	}
 }
 
+func TestNewStore_ShouldApplyOptions(t *testing.T) {
+	var applied []int
+	opt0 := func(s *Store) { applied = append(applied, 0) }
+	opt1 := func(s *Store) { applied = append(applied, 1) }
+
+	got := NewStore("some-folder", 10, opt0, opt1)
+
+	assert.NotNil(t, got)
+	assert.Equal(t, []int{0, 1}, applied)
+}
+
 func TestStore_LastPoint(t *testing.T) {
 	type field struct {
 		datasetDir string
@@ -119,11 +134,11 @@ func TestStore_LastPoint(t *testing.T) {
 			}
 			wantErr := tt.mocks.latestDatasetErr
 			if tt.mocks.latestDatasetErr != nil {
-				mockit.MockFunc(t, latestDataset).With(s.dir).Return("", wantErr)
+				mockit.MockFunc(t, latestDataset).With(s.dir, false).Return("", wantErr)
 			}
 			if tt.mocks.readRecordsErr != nil {
 				wantErr = tt.mocks.readRecordsErr
-				mockit.MockFunc(t, readRecords).With(filepath.Join(s.dir, "30_39.csv"), argument.Any).Return(wantErr)
+				mockit.MockFunc(t, readRecords).With(filepath.Join(s.dir, "30_39.csv"), argument.Any, argument.Any).Return(wantErr)
 			}
 
 			gotTimestamp, gotRecord, err := s.LastPoint()
@@ -247,22 +262,26 @@ func TestStore_StorePoints(t *testing.T) {
 		datasetContent string
 	}
 	type file struct {
-		path    string
-		content string
+		path     string
+		content  string
+		appended string
 	}
 	type args struct {
 		points TimeSeries
 	}
 	tests := []struct {
-		name  string
-		mocks mocks
-		args  args
-		want  []file
+		name      string
+		mocks     mocks
+		args      args
+		want      []file
+		wantStats InsertStats
 	}{
 		{
 			name: "Should return error if one occur while reading the datasets",
 			mocks: mocks{
-				readErr: errors.New("some-read-error"),
+				readErr:        errors.New("some-read-error"),
+				datasetName:    "10_19.csv",
+				datasetContent: "19,some-existing-value-at-19\n",
 			},
 			args: args{
 				points: &mockTimeSeries{
@@ -303,6 +322,7 @@ func TestStore_StorePoints(t *testing.T) {
 				{path: "0_9.csv", content: "0,some-value-at-0\n5,some-value-at-5\n9,some-value-at-9\n"},
 				{path: "10_19.csv", content: "10,some-value-at-10\n15,some-value-at-15\n19,some-value-at-19\n"},
 			},
+			wantStats: InsertStats{Inserted: 6},
 		},
 		{
 			name: "Should merge points with existing dataset",
@@ -322,18 +342,57 @@ func TestStore_StorePoints(t *testing.T) {
 			want: []file{
 				{path: "0_9.csv", content: "0,some-value-at-0\n1,some-value-at-1\n3,some-value-at-3\n5,some-value-at-5\n7,some-value-at-7\n9,some-value-at-9\n"},
 			},
+			wantStats: InsertStats{Inserted: 3},
+		},
+		{
+			name: "Should append points after the existing ones",
+			mocks: mocks{
+				datasetName:    "0_9.csv",
+				datasetContent: "1,some-value-at-1\n3,some-value-at-3\n",
+			},
+			args: args{
+				points: &mockTimeSeries{
+					points: []*dataPoint{
+						{timestamp: 12, record: []string{"some-value-at-12"}},
+						{timestamp: 5, record: []string{"some-value-at-5"}},
+						{timestamp: 4, record: []string{"some-value-at-4"}},
+					},
+				},
+			},
+			want: []file{
+				{path: "0_9.csv", content: "1,some-value-at-1\n3,some-value-at-3\n4,some-value-at-4\n5,some-value-at-5\n", appended: "4,some-value-at-4\n5,some-value-at-5\n"},
+				{path: "10_19.csv", content: "12,some-value-at-12\n"},
+			},
+			wantStats: InsertStats{Inserted: 3},
+		},
+		{
+			name: "Should rewrite dataset if its last line is not terminated",
+			mocks: mocks{
+				datasetName:    "0_9.csv",
+				datasetContent: "1,some-value-at-1\n3,some-value-at-3",
+			},
+			args: args{
+				points: &mockTimeSeries{
+					points: []*dataPoint{
+						{timestamp: 4, record: []string{"some-value-at-4"}},
+					},
+				},
+			},
+			want: []file{
+				{path: "0_9.csv", content: "1,some-value-at-1\n3,some-value-at-3\n4,some-value-at-4\n"},
+			},
+			wantStats: InsertStats{Inserted: 1},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			wantErr := tt.mocks.readErr
 			if tt.mocks.readErr != nil {
-				mockit.MockFunc(t, os.Stat).With(argument.Any).Return(nil, nil)
-				mockit.MockFunc(t, readRecords).With(argument.Any, argument.Any).Return(wantErr)
+				mockit.MockFunc(t, readRecords).With(argument.Any, argument.Any, argument.Any).Return(wantErr)
 			}
 			if tt.mocks.writeErr != nil {
 				wantErr = tt.mocks.writeErr
-				mockit.MockFunc(t, writeDatasets).With(argument.Any).Return(wantErr)
+				mockit.MockFunc(t, writeDatasets).With(argument.Any).Return(nil, wantErr)
 			}
 			dir := filestest.TempDir(t)
 			if len(tt.mocks.datasetName) > 0 {
@@ -346,16 +405,148 @@ func TestStore_StorePoints(t *testing.T) {
 				index: index{interval: 10},
 			}
 
-			err := s.StorePoints(tt.args.points)
+			got, err := s.StorePoints(tt.args.points)
 
 			assert.Equal(t, wantErr, err)
-			for _, file := range tt.want {
+			if wantErr != nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.wantStats, got.InsertStats)
+			assert.Len(t, got.Partitions, len(tt.want))
+			var wantBytes int64
+			for i, file := range tt.want {
 				filestest.FileExistsWithContent(t, filepath.Join(s.dir, file.path), file.content)
+				written := file.content
+				if len(file.appended) > 0 {
+					written = file.appended
+				}
+				assert.Equal(t, filepath.Join(s.dir, file.path), got.Partitions[i].Path)
+				assert.Equal(t, len(file.appended) > 0, got.Partitions[i].Appended)
+				assert.Equal(t, int64(len(written)), got.Partitions[i].Bytes)
+				assert.Equal(t, strings.Count(written, "\n"), got.Partitions[i].Rows)
+				wantBytes += int64(len(written))
 			}
+			assert.Equal(t, wantBytes, got.Bytes)
+			assert.True(t, got.Duration > 0)
 		})
 	}
 }
 
+func TestStore_StorePointsWithPolicy(t *testing.T) {
+	type args struct {
+		points *mockTimeSeries
+		policy ConflictPolicy
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      string
+		wantStats InsertStats
+		wantErr   error
+	}{
+		{
+			name: "Should replace existing points",
+			args: args{
+				points: &mockTimeSeries{
+					points: []*dataPoint{
+						{timestamp: 3, record: []string{"some-other-value-at-3"}},
+						{timestamp: 5, record: []string{"some-value-at-5"}},
+					},
+				},
+				policy: ReplaceOnConflict,
+			},
+			want:      "1,some-value-at-1\n3,some-other-value-at-3\n5,some-value-at-5\n",
+			wantStats: InsertStats{Inserted: 1, Replaced: 1},
+		},
+		{
+			name: "Should keep existing points",
+			args: args{
+				points: &mockTimeSeries{
+					points: []*dataPoint{
+						{timestamp: 3, record: []string{"some-other-value-at-3"}},
+						{timestamp: 5, record: []string{"some-value-at-5"}},
+					},
+				},
+				policy: KeepOnConflict,
+			},
+			want:      "1,some-value-at-1\n3,some-value-at-3\n5,some-value-at-5\n",
+			wantStats: InsertStats{Inserted: 1, Skipped: 1},
+		},
+		{
+			name: "Should not write anything if there is a conflict and the policy raises an error",
+			args: args{
+				points: &mockTimeSeries{
+					points: []*dataPoint{
+						{timestamp: 3, record: []string{"some-other-value-at-3"}},
+						{timestamp: 5, record: []string{"some-value-at-5"}},
+					},
+				},
+				policy: ErrorOnConflict,
+			},
+			want:    "1,some-value-at-1\n3,some-value-at-3\n",
+			wantErr: errors.New("data point already exists: 3"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filestest.TempDir(t)
+			reader := strings.NewReader("1,some-value-at-1\n3,some-value-at-3\n")
+			ioutilx.ReaderToFile(reader, filepath.Join(dir, "0_9.csv"))
+			s := NewStore(dir, 10)
+
+			got, err := s.StorePointsWithPolicy(tt.args.points, tt.args.policy)
+
+			if tt.wantErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.wantStats, got.InsertStats)
+				assert.Equal(t, tt.wantStats, got.Partitions[0].InsertStats)
+			}
+			filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), tt.want)
+		})
+	}
+}
+
+func TestStore_StorePointsWithPolicy_ShouldKeepTheOrderOfPointsWithTheSameTimestamp(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10)
+	var points Points
+	for i := 0; i < 50; i++ {
+		points = append(points, Point{Timestamp: uint64(9 - i%10), Record: []string{strconv.Itoa(i)}})
+	}
+
+	_, err := s.StorePointsWithPolicy(points, ReplaceOnConflict)
+
+	assert.Nil(t, err)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "0,49\n1,48\n2,47\n3,46\n4,45\n5,44\n6,43\n7,42\n8,41\n9,40\n")
+}
+
+func TestStore_StorePointsWithPolicy_ShouldReturnErrorIfTimestampFormatIsInvalid(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10, WithTimestampFormat(TimestampFormat{Column: -1}))
+
+	got, err := s.StorePointsWithPolicy(Points{{Timestamp: 1, Record: []string{"a"}}}, ReplaceOnConflict)
+
+	assert.True(t, errors.Is(err, ErrInvalidTimestampFormat))
+	assert.Nil(t, got)
+	assert.NoFileExists(t, filepath.Join(dir, "0_9.csv"))
+}
+
+func TestStore_StorePointsWithPolicy_ShouldReturnErrorIfCommentsAreSetWithTimestampsNotInFirstColumn(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10, WithDialect(Dialect{Comment: '#'}), WithTimestampFormat(TimestampFormat{Column: 1}))
+
+	got, err := s.StorePointsWithPolicy(Points{{Timestamp: 1, Record: []string{"#a"}}}, ReplaceOnConflict)
+
+	assert.True(t, errors.Is(err, ErrInvalidDialect))
+	assert.Nil(t, got)
+	assert.NoFileExists(t, filepath.Join(dir, "0_9.csv"))
+}
+
 func TestStore_merge(t *testing.T) {
 	type fields struct {
 		interval uint64
@@ -365,10 +556,11 @@ func TestStore_merge(t *testing.T) {
 		points   *mockTimeSeries
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   []*dataset
+		name      string
+		fields    fields
+		args      args
+		want      []*dataset
+		wantStats InsertStats
 	}{
 		{
 			name: "Interval = 10",
@@ -419,6 +611,7 @@ func TestStore_merge(t *testing.T) {
 					},
 				},
 			},
+			wantStats: InsertStats{Inserted: 4, Replaced: 2},
 		},
 		{
 			name: "Interval = 5",
@@ -463,6 +656,7 @@ func TestStore_merge(t *testing.T) {
 					},
 				},
 			},
+			wantStats: InsertStats{Inserted: 1, Replaced: 2},
 		},
 		{
 			name: "Should add dataset if it does not exist",
@@ -494,6 +688,7 @@ func TestStore_merge(t *testing.T) {
 					},
 				},
 			},
+			wantStats: InsertStats{Inserted: 3},
 		},
 	}
 	for _, tt := range tests {
@@ -508,129 +703,57 @@ func TestStore_merge(t *testing.T) {
 				datasetsMap[ds.points[0].timestamp] = ds
 			}
 
-			s.merge(datasetsMap, tt.args.points)
+			stats, err := s.merge(datasetsMap, tt.args.points, nil)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantStats, stats)
 
 			for _, ds := range tt.want {
-				assert.Equal(t, ds, datasetsMap[ds.points[0].timestamp])
+				got := datasetsMap[ds.points[0].timestamp]
+				assert.Equal(t, ds.path, got.path)
+				assert.Equal(t, ds.points, got.points)
 			}
 		})
 	}
 }
 
-func TestStore_readDatasets(t *testing.T) {
+func TestStore_readDataset(t *testing.T) {
 	type fields struct {
 		dir      string
 		interval uint64
 	}
-	type args struct {
-		from uint64
-		to   uint64
-	}
-	type wantDs struct {
-		ds  dataset
-		key uint64
-	}
 	tests := []struct {
 		name    string
 		fields  fields
-		args    args
-		want    []wantDs
+		dataset string
+		want    dataPointList
 		wantErr error
 	}{
 		{
-			name: "Should load one dataset",
-			fields: fields{
-				dir:      "small_interval",
-				interval: 10,
-			},
-			args: args{
-				from: 0,
-				to:   9,
-			},
-			want: []wantDs{
-				{
-					ds: dataset{
-						path: filepath.Join("testdata", "datasets", "small_interval", "0_9.csv"),
-						points: []*dataPoint{
-							{0, []string{"something-value-at-0"}},
-							{8, []string{"something-value-at-8"}},
-							{9, []string{"something-value-at-9"}},
-						},
-					},
-					key: 0,
-				},
-			},
-		},
-		{
-			name: "Should load one dataset with no sample for the first timestamp",
+			name: "Should load dataset",
 			fields: fields{
 				dir:      "small_interval",
 				interval: 10,
 			},
-			args: args{
-				from: 13,
-				to:   17,
-			},
-			want: []wantDs{
-				{
-					ds: dataset{
-						path: filepath.Join("testdata", "datasets", "small_interval", "10_19.csv"),
-						points: []*dataPoint{
-							{11, []string{"something-value-at-11"}},
-							{13, []string{"something-value-at-13"}},
-							{19, []string{"something-value-at-19"}},
-						},
-					},
-					key: 10,
-				},
+			dataset: "0_9.csv",
+			want: []*dataPoint{
+				{0, []string{"something-value-at-0"}},
+				{8, []string{"something-value-at-8"}},
+				{9, []string{"something-value-at-9"}},
 			},
 		},
 		{
-			name: "Should load two dataset",
+			name: "Should load another dataset",
 			fields: fields{
 				dir:      "small_interval",
 				interval: 10,
 			},
-			args: args{
-				from: 0,
-				to:   19,
+			dataset: "10_19.csv",
+			want: []*dataPoint{
+				{11, []string{"something-value-at-11"}},
+				{13, []string{"something-value-at-13"}},
+				{19, []string{"something-value-at-19"}},
 			},
-			want: []wantDs{
-				{
-					ds: dataset{
-						path: filepath.Join("testdata", "datasets", "small_interval", "0_9.csv"),
-						points: []*dataPoint{
-							{0, []string{"something-value-at-0"}},
-							{8, []string{"something-value-at-8"}},
-							{9, []string{"something-value-at-9"}},
-						},
-					},
-					key: 0,
-				},
-				{
-					ds: dataset{
-						path: filepath.Join("testdata", "datasets", "small_interval", "10_19.csv"),
-						points: []*dataPoint{
-							{11, []string{"something-value-at-11"}},
-							{13, []string{"something-value-at-13"}},
-							{19, []string{"something-value-at-19"}},
-						},
-					},
-					key: 10,
-				},
-			},
-		},
-		{
-			name: "Should return empty map if no dataset exists",
-			fields: fields{
-				dir:      "small_interval",
-				interval: 10,
-			},
-			args: args{
-				from: 40,
-				to:   49,
-			},
-			want: make([]wantDs, 0),
 		},
 		{
 			name: "Should return error if readRecords raises it",
@@ -638,12 +761,9 @@ func TestStore_readDatasets(t *testing.T) {
 				dir:      "small_interval",
 				interval: 10,
 			},
-			args: args{
-				from: 0,
-				to:   29,
-			},
+			dataset: "20_29.csv",
 			want:    nil,
-			wantErr: errors.New("strconv.ParseUint: parsing \"invalid-record\": invalid syntax"),
+			wantErr: errors.New("testdata/datasets/small_interval/20_29.csv:1: invalid timestamp \"invalid-record\" in column 0: strconv.ParseUint: parsing \"invalid-record\": invalid syntax"),
 		},
 		{
 			name: "Should not raise error if the interval is greater than maxInt64",
@@ -651,19 +771,8 @@ func TestStore_readDatasets(t *testing.T) {
 				dir:      "large_interval",
 				interval: math.MaxInt64 + 1,
 			},
-			args: args{
-				from: 0,
-				to:   math.MaxInt64,
-			},
-			want: []wantDs{
-				{
-					ds: dataset{
-						path:   filepath.Join("testdata", "datasets", "large_interval", "0_9223372036854775807.csv"),
-						points: []*dataPoint{},
-					},
-					key: 0,
-				},
-			},
+			dataset: "0_9223372036854775807.csv",
+			want:    []*dataPoint{},
 			wantErr: nil,
 		},
 	}
@@ -676,7 +785,7 @@ func TestStore_readDatasets(t *testing.T) {
 				},
 			}
 
-			got, err := s.readDatasets(tt.args.from, tt.args.to)
+			got, err := s.readDataset(s.path(tt.dataset))
 
 			if tt.wantErr != nil {
 				assert.NotNil(t, err)
@@ -684,10 +793,360 @@ func TestStore_readDatasets(t *testing.T) {
 			} else {
 				assert.Nil(t, err)
 			}
-			assert.Equal(t, len(tt.want), len(got))
-			for _, d := range tt.want {
-				assert.Equal(t, &d.ds, got[d.key])
-			}
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func TestStore_WriteBuffer(t *testing.T) {
+	dir := filestest.TempDir(t)
+	err := ioutilx.ReaderToFile(strings.NewReader("1,some-value-at-1\n3,some-value-at-3\n"), filepath.Join(dir, "0_9.csv"))
+	assert.Nil(t, err)
+	s := NewStore(dir, 10, WithWriteBuffer(4, 0))
+
+	got, err := s.StorePoints(&mockTimeSeries{
+		points: []*dataPoint{
+			{timestamp: 12, record: []string{"some-value-at-12"}},
+			{timestamp: 3, record: []string{"some-other-value-at-3"}},
+			{timestamp: 2, record: []string{"some-value-at-2"}},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, InsertStats{Inserted: 2, Replaced: 1}, got.Buffered)
+	assert.Empty(t, got.Partitions)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "1,some-value-at-1\n3,some-value-at-3\n")
+	assert.NoFileExists(t, filepath.Join(dir, "10_19.csv"))
+
+	var loaded []*dataPoint
+	err = s.LoadPoints(0, 19, func(timestamp uint64, record []string) error {
+		loaded = append(loaded, &dataPoint{timestamp: timestamp, record: record})
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []*dataPoint{
+		{timestamp: 1, record: []string{"some-value-at-1"}},
+		{timestamp: 2, record: []string{"some-value-at-2"}},
+		{timestamp: 3, record: []string{"some-other-value-at-3"}},
+		{timestamp: 12, record: []string{"some-value-at-12"}},
+	}, loaded)
+
+	timestamp, record, err := s.LastPoint()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(12), timestamp)
+	assert.Equal(t, []string{"some-value-at-12"}, record)
+
+	got, err = s.StorePoints(&mockTimeSeries{
+		points: []*dataPoint{
+			{timestamp: 15, record: []string{"some-value-at-15"}},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, InsertStats{Inserted: 1}, got.Buffered)
+	assert.Equal(t, InsertStats{Inserted: 3, Replaced: 1}, got.InsertStats)
+	assert.Len(t, got.Partitions, 2)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "1,some-value-at-1\n2,some-value-at-2\n3,some-other-value-at-3\n")
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "10_19.csv"), "12,some-value-at-12\n15,some-value-at-15\n")
+
+	_, err = s.StorePoints(&mockTimeSeries{
+		points: []*dataPoint{
+			{timestamp: 16, record: []string{"some-value-at-16"}},
+		},
+	})
+	assert.Nil(t, err)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "10_19.csv"), "12,some-value-at-12\n15,some-value-at-15\n")
+
+	err = s.Close()
+
+	assert.Nil(t, err)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "10_19.csv"), "12,some-value-at-12\n15,some-value-at-15\n16,some-value-at-16\n")
+}
+
+func TestStore_WriteBuffer_ShouldRejectPointsConflictingWithPersistedOnes(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10, WithWriteBuffer(0, 0), WithConflictPolicy(ErrorOnConflict))
+	_, err := s.StorePoints(Points{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 2, Record: []string{"b"}}})
+	assert.Nil(t, err)
+	_, err = s.Flush()
+	assert.Nil(t, err)
+
+	_, err = s.StorePoints(Points{{Timestamp: 2, Record: []string{"c"}}})
+
+	assert.True(t, errors.Is(err, ErrConflict))
+	_, err = s.StorePoints(Points{{Timestamp: 3, Record: []string{"d"}}})
+	assert.Nil(t, err)
+	err = s.Close()
+	assert.Nil(t, err)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "1,a\n2,b\n3,d\n")
+}
+
+func TestStore_WriteBuffer_ShouldDiscardPointsRejectedWhenFlushing(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10, WithWriteBuffer(0, 0), WithConflictPolicy(ErrorOnConflict))
+	_, err := s.StorePoints(Points{{Timestamp: 2, Record: []string{"b"}}})
+	assert.Nil(t, err)
+	err = ioutilx.ReaderToFile(strings.NewReader("2,a\n"), filepath.Join(dir, "0_9.csv"))
+	assert.Nil(t, err)
+
+	_, err = s.StorePoints(Points{{Timestamp: 4, Record: []string{"d"}}})
+	assert.Nil(t, err)
+
+	_, err = s.Flush()
+
+	assert.True(t, errors.Is(err, ErrConflict))
+	var rejected *RejectedPointsError
+	assert.True(t, errors.As(err, &rejected))
+	assert.Equal(t, []uint64{2}, rejected.Timestamps)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "2,a\n4,d\n")
+	_, err = s.StorePoints(Points{{Timestamp: 3, Record: []string{"c"}}})
+	assert.Nil(t, err)
+	err = s.Close()
+	assert.Nil(t, err)
+	err = s.Close()
+	assert.Nil(t, err)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "2,a\n3,c\n4,d\n")
+}
+
+func TestStore_WriteBuffer_ShouldNotReadPartitionIfPointsAreOutsideItsRange(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10, WithWriteBuffer(0, 0), WithConflictPolicy(ErrorOnConflict))
+	_, err := s.StorePointsWithPolicy(Points{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 5, Record: []string{"b"}}}, ErrorOnConflict)
+	assert.Nil(t, err)
+	// same size, so the metadata is still trusted, but not readable
+	err = ioutilx.ReaderToFile(strings.NewReader("x,a\n5,b\n"), filepath.Join(dir, "0_9.csv"))
+	assert.Nil(t, err)
+
+	got, err := s.StorePoints(Points{{Timestamp: 0, Record: []string{"c"}}, {Timestamp: 7, Record: []string{"d"}}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, InsertStats{Inserted: 2}, got.Buffered)
+	_, err = s.StorePoints(Points{{Timestamp: 3, Record: []string{"e"}}})
+	assert.NotNil(t, err)
+}
+
+func TestStore_WriteBuffer_ShouldReturnBufferedPointIfNoDatasetExists(t *testing.T) {
+	s := NewStore(filepath.Join(filestest.TempDir(t), "some-not-existing-folder"), 10, WithWriteBuffer(0, 0))
+	_, err := s.StorePoints(&mockTimeSeries{
+		points: []*dataPoint{
+			{timestamp: 5, record: []string{"some-value-at-5"}},
+		},
+	})
+	assert.Nil(t, err)
+
+	timestamp, record, err := s.LastPoint()
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), timestamp)
+	assert.Equal(t, []string{"some-value-at-5"}, record)
+}
+
+func TestStore_WriteBuffer_ShouldFlushPeriodically(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10, WithWriteBuffer(0, time.Millisecond))
+	defer s.Close()
+
+	_, err := s.StorePoints(&mockTimeSeries{
+		points: []*dataPoint{
+			{timestamp: 5, record: []string{"some-value-at-5"}},
+		},
+	})
+	assert.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		content, err := ioutil.ReadFile(filepath.Join(dir, "0_9.csv"))
+		return err == nil && string(content) == "5,some-value-at-5\n"
+	}, time.Second, time.Millisecond)
+}
+
+func TestStore_WriteBuffer_ShouldFlushBeforeWritingWithPolicy(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10, WithWriteBuffer(0, 0))
+	_, err := s.StorePoints(&mockTimeSeries{
+		points: []*dataPoint{
+			{timestamp: 5, record: []string{"some-value-at-5"}},
+		},
+	})
+	assert.Nil(t, err)
+
+	got, err := s.StorePointsWithPolicy(&mockTimeSeries{
+		points: []*dataPoint{
+			{timestamp: 5, record: []string{"some-other-value-at-5"}},
+		},
+	}, KeepOnConflict)
+
+	assert.Nil(t, err)
+	assert.Equal(t, InsertStats{Skipped: 1}, got.InsertStats)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "5,some-value-at-5\n")
+}
+
+func TestStore_Compression(t *testing.T) {
+	dir := filestest.TempDir(t)
+	err := ioutilx.ReaderToFile(strings.NewReader("1,some-value-at-1\n3,some-value-at-3\n"), filepath.Join(dir, "0_9.csv"))
+	assert.Nil(t, err)
+	err = ioutilx.ReaderToFile(strings.NewReader("11,some-value-at-11\n"), filepath.Join(dir, "10_19.csv"))
+	assert.Nil(t, err)
+	s := NewStore(dir, 10, WithCompression(Gzip))
+
+	got, err := s.AppendBatch([]Point{
+		{Timestamp: 2, Record: []string{"some-value-at-2"}},
+		{Timestamp: 12, Record: []string{"some-value-at-12"}},
+		{Timestamp: 21, Record: []string{"some-value-at-21"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "0_9.csv.gz"), got.Partitions[0].Path)
+	assert.False(t, got.Partitions[0].Appended)
+	assert.Equal(t, filepath.Join(dir, "10_19.csv"), got.Partitions[1].Path)
+	assert.True(t, got.Partitions[1].Appended)
+	assert.Equal(t, filepath.Join(dir, "20_29.csv.gz"), got.Partitions[2].Path)
+	assert.NoFileExists(t, filepath.Join(dir, "0_9.csv"))
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "10_19.csv"), "11,some-value-at-11\n12,some-value-at-12\n")
+
+	err = s.Append(22, []string{"some-value-at-22"})
+	assert.Nil(t, err)
+
+	var loaded []Point
+	err = s.LoadPoints(0, 29, func(timestamp uint64, record []string) error {
+		loaded = append(loaded, Point{Timestamp: timestamp, Record: record})
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []Point{
+		{Timestamp: 1, Record: []string{"some-value-at-1"}},
+		{Timestamp: 2, Record: []string{"some-value-at-2"}},
+		{Timestamp: 3, Record: []string{"some-value-at-3"}},
+		{Timestamp: 11, Record: []string{"some-value-at-11"}},
+		{Timestamp: 12, Record: []string{"some-value-at-12"}},
+		{Timestamp: 21, Record: []string{"some-value-at-21"}},
+		{Timestamp: 22, Record: []string{"some-value-at-22"}},
+	}, loaded)
+
+	timestamp, record, err := s.LastPoint()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(22), timestamp)
+	assert.Equal(t, []string{"some-value-at-22"}, record)
+}
+
+func TestStore_Dialect(t *testing.T) {
+	dir := filestest.TempDir(t)
+	err := ioutilx.ReaderToFile(strings.NewReader("# some comment\n1; some-value-at-1\n3;\"some;value-at-3\"\n"), filepath.Join(dir, "0_9.csv"))
+	assert.Nil(t, err)
+	err = ioutilx.ReaderToFile(strings.NewReader("11;some-value-at-11\r\n"), filepath.Join(dir, "10_19.csv"))
+	assert.Nil(t, err)
+	s := NewStore(dir, 10, WithDialect(Dialect{Delimiter: ';', Comment: '#', TrimLeadingSpace: true, UseCRLF: true}))
+
+	got, err := s.AppendBatch([]Point{
+		{Timestamp: 2, Record: []string{"some-value-at-2"}},
+		{Timestamp: 12, Record: []string{"some-value-at-12"}},
+	})
+	assert.Nil(t, err)
+	assert.False(t, got.Partitions[0].Appended)
+	assert.True(t, got.Partitions[1].Appended)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9.csv"), "1;some-value-at-1\r\n2;some-value-at-2\r\n3;\"some;value-at-3\"\r\n")
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "10_19.csv"), "11;some-value-at-11\r\n12;some-value-at-12\r\n")
+
+	var loaded []Point
+	err = s.LoadPoints(0, 19, func(timestamp uint64, record []string) error {
+		loaded = append(loaded, Point{Timestamp: timestamp, Record: record})
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []Point{
+		{Timestamp: 1, Record: []string{"some-value-at-1"}},
+		{Timestamp: 2, Record: []string{"some-value-at-2"}},
+		{Timestamp: 3, Record: []string{"some;value-at-3"}},
+		{Timestamp: 11, Record: []string{"some-value-at-11"}},
+		{Timestamp: 12, Record: []string{"some-value-at-12"}},
+	}, loaded)
+
+	timestamp, record, err := s.LastPoint()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(12), timestamp)
+	assert.Equal(t, []string{"some-value-at-12"}, record)
+
+	report, err := s.Verify()
+	assert.Nil(t, err)
+	assert.True(t, report.OK())
+}
+
+func TestStore_Dialect_ShouldCheckFieldsPerRecord(t *testing.T) {
+	dir := filestest.TempDir(t)
+	err := ioutilx.ReaderToFile(strings.NewReader("1\tsome-value-at-1\n2\tsome-value-at-2\tsome-other-value\n"), filepath.Join(dir, "0_9.csv"))
+	assert.Nil(t, err)
+
+	err = NewStore(dir, 10, WithDialect(Dialect{Delimiter: '\t'})).LoadPoints(0, 9, func(uint64, []string) error { return nil })
+	assert.True(t, errors.Is(err, csv.ErrFieldCount))
+
+	err = NewStore(dir, 10, WithDialect(Dialect{Delimiter: '\t', FieldsPerRecord: -1})).LoadPoints(0, 9, func(uint64, []string) error { return nil })
+	assert.Nil(t, err)
+
+	err = NewStore(dir, 10, WithDialect(Dialect{Delimiter: '\t', FieldsPerRecord: 3})).LoadPoints(0, 9, func(uint64, []string) error { return nil })
+	assert.True(t, errors.Is(err, csv.ErrFieldCount))
+	var partitionErr *PartitionError
+	assert.True(t, errors.As(err, &partitionErr))
+	assert.Equal(t, 1, partitionErr.Line)
+}
+
+func TestStore_TimestampFormat(t *testing.T) {
+	dir := filestest.TempDir(t)
+	err := ioutilx.ReaderToFile(strings.NewReader("a,b,2020-01-01T00:00:02Z\nc,d,2020-01-01T00:00:01Z\n"), filepath.Join(dir, "1577836800_1577836859.csv"))
+	assert.Nil(t, err)
+	s := NewStore(dir, 60, WithTimestampFormat(TimestampFormat{Column: 2, Layout: time.RFC3339}))
+
+	_, err = s.Repair()
+	assert.Nil(t, err)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "1577836800_1577836859.csv"), "c,d,2020-01-01T00:00:01Z\na,b,2020-01-01T00:00:02Z\n")
+
+	got, err := s.AppendBatch([]Point{{Timestamp: 1577836803, Record: []string{"e", "f"}}})
+	assert.Nil(t, err)
+	assert.True(t, got.Partitions[0].Appended)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "1577836800_1577836859.csv"), "c,d,2020-01-01T00:00:01Z\na,b,2020-01-01T00:00:02Z\ne,f,2020-01-01T00:00:03Z\n")
+
+	var loaded []Point
+	err = s.LoadPoints(1577836800, 1577836859, func(timestamp uint64, record []string) error {
+		loaded = append(loaded, Point{Timestamp: timestamp, Record: record})
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []Point{
+		{Timestamp: 1577836801, Record: []string{"c", "d"}},
+		{Timestamp: 1577836802, Record: []string{"a", "b"}},
+		{Timestamp: 1577836803, Record: []string{"e", "f"}},
+	}, loaded)
+
+	timestamp, record, err := s.LastPoint()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1577836803), timestamp)
+	assert.Equal(t, []string{"e", "f"}, record)
+}
+
+func TestStore_TimestampFormat_ShouldKeepThePrecisionOfTheUnit(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10000, WithTimeUnit(Millisecond), WithTimestampFormat(TimestampFormat{Layout: time.RFC3339Nano}))
+
+	_, err := s.StorePointsWithPolicy(Points{
+		{Timestamp: 1500, Record: []string{"a"}},
+		{Timestamp: 1750, Record: []string{"b"}},
+	}, ReplaceOnConflict)
+
+	assert.Nil(t, err)
+	filestest.FileExistsWithContent(t, filepath.Join(dir, "0_9999.csv"), "1970-01-01T00:00:01.5Z,a\n1970-01-01T00:00:01.75Z,b\n")
+	assert.Equal(t, []Point{
+		{Timestamp: 1500, Record: []string{"a"}},
+		{Timestamp: 1750, Record: []string{"b"}},
+	}, loadAll(t, s, 0, 9999))
+}
+
+func TestStore_TimestampFormat_ShouldReturnErrorIfTheLayoutCantKeepThePrecisionOfTheUnit(t *testing.T) {
+	dir := filestest.TempDir(t)
+	s := NewStore(dir, 10000, WithTimeUnit(Millisecond), WithTimestampFormat(TimestampFormat{Layout: time.RFC3339}))
+
+	got, err := s.StorePointsWithPolicy(Points{
+		{Timestamp: 1500, Record: []string{"a"}},
+		{Timestamp: 1750, Record: []string{"b"}},
+	}, ReplaceOnConflict)
+
+	assert.True(t, errors.Is(err, ErrInvalidTimestampFormat))
+	assert.Nil(t, got)
+	assert.NoFileExists(t, filepath.Join(dir, "0_9999.csv"))
+}
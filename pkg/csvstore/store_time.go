@@ -0,0 +1,62 @@
+package csvstore
+
+import (
+	"time"
+)
+
+// LoadTimePoints is like LoadPoints, but with time.Time timestamps, converted
+// with the time unit of the store. If the timestamps are not signed, the range
+// is limited to the ones after the Unix epoch.
+func (s *Store) LoadTimePoints(from time.Time, to time.Time, pointHandler func(time.Time, []string) error) error {
+	toTimestamp, err := s.timestamp(to)
+	if err != nil {
+		if err == ErrBeforeEpoch {
+			return nil
+		}
+		return err
+	}
+	fromTimestamp, err := s.timestamp(from)
+	if err != nil && err != ErrBeforeEpoch {
+		return err
+	}
+
+	return s.LoadPoints(fromTimestamp, toTimestamp, func(timestamp uint64, record []string) error {
+		return pointHandler(s.time(timestamp), record)
+	})
+}
+
+// StoreTimePoints is like StorePoints, but with time.Time timestamps,
+// converted with the time unit of the store, truncating the finer precision
+func (s *Store) StoreTimePoints(points []TimePoint) (*WriteResult, error) {
+	converted := make(Points, len(points))
+	for i, p := range points {
+		timestamp, err := s.timestamp(p.Time)
+		if err != nil {
+			return nil, err
+		}
+		converted[i] = Point{Timestamp: timestamp, Record: p.Record}
+	}
+
+	return s.StorePoints(converted)
+}
+
+// LastTimePoint is like LastPoint, but with a time.Time timestamp, converted
+// with the time unit of the store; it is the zero time if the store is empty
+func (s *Store) LastTimePoint() (time.Time, []string, error) {
+	timestamp, record, err := s.LastPoint()
+	if err != nil || record == nil {
+		return time.Time{}, nil, err
+	}
+
+	return s.time(timestamp), record, nil
+}
+
+// timestamp returns the timestamp of the time, in the unit of the store
+func (s *Store) timestamp(t time.Time) (uint64, error) {
+	return toTimestamp(s.unit.fromTime(t), s.index.signed)
+}
+
+// time returns the UTC time of the timestamp, in the unit of the store
+func (s *Store) time(timestamp uint64) time.Time {
+	return s.unit.toTime(fromTimestamp(timestamp, s.index.signed))
+}
@@ -0,0 +1,99 @@
+package csvstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_TimePoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		points    []TimePoint
+		wantFiles map[string]string
+		wantErr   error
+	}{
+		{
+			name: "Should store points in milliseconds",
+			opts: []Option{WithTimeUnit(Millisecond)},
+			points: []TimePoint{
+				{Time: time.Date(1970, 1, 1, 0, 0, 1, 500000000, time.UTC), Record: []string{"a"}},
+				{Time: time.Date(1970, 1, 1, 0, 0, 0, 2000000, time.UTC), Record: []string{"b"}},
+			},
+			wantFiles: map[string]string{
+				"0_999.csv":     "2,b\n",
+				"1000_1999.csv": "1500,a\n",
+			},
+		},
+		{
+			name: "Should store points before the epoch in signed mode",
+			opts: []Option{WithSignedTimestamps()},
+			points: []TimePoint{
+				{Time: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), Record: []string{"a"}},
+				{Time: time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC), Record: []string{"b"}},
+				{Time: time.Date(1969, 12, 31, 23, 43, 19, 0, time.UTC), Record: []string{"c"}},
+			},
+			wantFiles: map[string]string{
+				"-2000_-1001.csv": "-1001,c\n",
+				"-1000_-1.csv":    "-1,a\n",
+				"0_999.csv":       "1,b\n",
+			},
+		},
+		{
+			name: "Should return error for points before the epoch in unsigned mode",
+			points: []TimePoint{
+				{Time: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), Record: []string{"a"}},
+			},
+			wantErr: ErrBeforeEpoch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := OpenStore(filestest.TempDir(t), 1000, tt.opts...)
+			assert.Nil(t, err)
+
+			_, err = s.StoreTimePoints(tt.points)
+
+			assert.Equal(t, tt.wantErr, err)
+			if err != nil {
+				return
+			}
+			for name, content := range tt.wantFiles {
+				filestest.FileExistsWithContent(t, s.path(name), content)
+			}
+
+			var got []TimePoint
+			err = s.LoadTimePoints(time.Date(1969, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(1970, 1, 1, 1, 0, 0, 0, time.UTC), func(t time.Time, record []string) error {
+				got = append(got, TimePoint{Time: t, Record: record})
+				return nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, len(tt.points), len(got))
+			for i := 1; i < len(got); i++ {
+				assert.True(t, got[i-1].Time.Before(got[i].Time))
+			}
+
+			last, record, err := s.LastTimePoint()
+			assert.Nil(t, err)
+			assert.Equal(t, got[len(got)-1], TimePoint{Time: last, Record: record})
+
+			report, err := s.Verify()
+			assert.Nil(t, err)
+			assert.True(t, report.OK())
+		})
+	}
+}
+
+func TestStore_LastTimePoint_ShouldReturnZeroTimeIfEmpty(t *testing.T) {
+	s, err := OpenStore(filestest.TempDir(t), 10)
+	assert.Nil(t, err)
+
+	got, record, err := s.LastTimePoint()
+
+	assert.Nil(t, err)
+	assert.True(t, got.IsZero())
+	assert.Nil(t, record)
+}
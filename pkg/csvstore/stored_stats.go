@@ -0,0 +1,63 @@
+package csvstore
+
+import "os"
+
+// storedStats resolves, with the policy, the conflicts between the sorted
+// points and the ones persisted in their partitions, and returns the number of
+// points that would replace or be skipped, failing with the error of the
+// policy if any. The partitions are read only if some points are in the
+// range of their metadata, or if it is missing.
+func (s *Store) storedStats(points dataPointList, policy ConflictPolicy) (InsertStats, error) {
+	var stats InsertStats
+	for start := 0; start < len(points); {
+		from, to := timestampToInterval(points[start].timestamp, s.index.interval, s.index.signed)
+		end := start + 1
+		for end < len(points) && points[end].timestamp <= to {
+			end++
+		}
+		batch := points[start:end]
+		start = end
+
+		path, err := s.locate(s.path(datasetName(from, to, s.index.signed)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return InsertStats{}, err
+		}
+
+		// only the points in the range of the persisted ones can conflict
+		meta, err := storedPartitionMeta(path)
+		if err != nil {
+			return InsertStats{}, err
+		}
+		if meta != nil {
+			batch = meta.within(batch)
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		appendable, err := s.canAppend(path, batch[0].timestamp)
+		if err != nil {
+			return InsertStats{}, err
+		}
+		if appendable {
+			continue
+		}
+
+		existing, err := s.readDataset(path)
+		if err != nil {
+			return InsertStats{}, err
+		}
+
+		_, batchStats, err := mergePoints(existing, batch, policy)
+		if err != nil {
+			return InsertStats{}, err
+		}
+		stats.Replaced += batchStats.Replaced
+		stats.Skipped += batchStats.Skipped
+	}
+
+	return stats, nil
+}
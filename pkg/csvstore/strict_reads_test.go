@@ -0,0 +1,88 @@
+package csvstore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_LoadPoints_StrictReads(t *testing.T) {
+	tests := []struct {
+		name     string
+		strict   bool
+		content  string
+		columnar bool
+		want     []Point
+		wantErr  *PartitionError
+	}{
+		{
+			name:    "Should return misplaced rows if strict reads are disabled",
+			content: "1,a\n12,b\n",
+			want:    []Point{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 12, Record: []string{"b"}}},
+		},
+		{
+			name:    "Should read valid partitions",
+			strict:  true,
+			content: "1,a\n2,b\n",
+			want:    []Point{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 2, Record: []string{"b"}}},
+		},
+		{
+			name:    "Should return error if a row is out of range",
+			strict:  true,
+			content: "1,a\n\"2\",\"multi\nline\"\n12,b\n",
+			wantErr: &PartitionError{Path: "0_9.csv", Line: 4, Value: "12", Kind: ErrOutOfRange},
+		},
+		{
+			name:    "Should return error if a row is out of order",
+			strict:  true,
+			content: "2,a\n1,b\n",
+			wantErr: &PartitionError{Path: "0_9.csv", Line: 2, Value: "1", Kind: ErrOutOfOrder},
+		},
+		{
+			name:     "Should return error if a row of a columnar partition is out of order",
+			strict:   true,
+			columnar: true,
+			content:  "2,a\n1,b\n",
+			wantErr:  &PartitionError{Path: "0_9.col", Line: 2, Value: "1", Kind: ErrOutOfOrder},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.strict {
+				opts = append(opts, WithStrictReads())
+			}
+			s := NewStore(filestest.TempDir(t), 10, opts...)
+			err := ioutil.WriteFile(s.path("0_9.csv"), []byte(tt.content), 0644)
+			assert.Nil(t, err)
+			if tt.columnar {
+				points, err := (&Store{dir: s.dir, index: s.index}).readDataset(s.path("0_9.csv"))
+				assert.Nil(t, err)
+				_, err = writeColumnar(s.path("0_9.col"), points)
+				assert.Nil(t, err)
+				err = os.Remove(s.path("0_9.csv"))
+				assert.Nil(t, err)
+			}
+
+			var got []Point
+			err = s.LoadPoints(0, 19, func(timestamp uint64, record []string) error {
+				got = append(got, Point{Timestamp: timestamp, Record: record})
+				return nil
+			})
+
+			if tt.wantErr != nil {
+				var partitionErr *PartitionError
+				assert.True(t, errors.As(err, &partitionErr))
+				tt.wantErr.Path = s.path(tt.wantErr.Path)
+				assert.Equal(t, tt.wantErr, partitionErr)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
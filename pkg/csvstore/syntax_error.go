@@ -0,0 +1,18 @@
+package csvstore
+
+import "fmt"
+
+// SyntaxError is returned when a statement of the query language is not
+// valid
+type SyntaxError struct {
+	// Pos is the position in the statement, starting from 1, of the token
+	// that caused the error
+	Pos int
+
+	// Msg describes the error
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
@@ -0,0 +1,44 @@
+package csvstore
+
+import (
+	"strconv"
+	"time"
+)
+
+// timeBound is a time in a statement of the query language, either a raw
+// timestamp or a time converted with the unit of the store
+type timeBound struct {
+	op        CompareOp
+	timestamp int64
+	time      time.Time
+	isTime    bool
+}
+
+// newTimeBound parses the literal compared with the time, an integer
+// timestamp or an RFC 3339 string
+func newTimeBound(op CompareOp, literal qlToken) (*timeBound, error) {
+	if op == NotEqual {
+		return nil, &SyntaxError{Pos: literal.pos, Msg: "time cannot be compared with " + op.String()}
+	}
+	switch literal.kind {
+	case qlNumber:
+		timestamp, err := strconv.ParseInt(literal.text, 10, 64)
+		if err == nil {
+			return &timeBound{op: op, timestamp: timestamp}, nil
+		}
+	case qlString:
+		t, err := time.Parse(time.RFC3339Nano, literal.text)
+		if err == nil {
+			return &timeBound{op: op, time: t, isTime: true}, nil
+		}
+	}
+	return nil, &SyntaxError{Pos: literal.pos, Msg: "invalid time " + literal.String() + ", expected an integer timestamp or an RFC 3339 string"}
+}
+
+// resolve returns the timestamp of the bound in the store
+func (b *timeBound) resolve(s *Store) (uint64, error) {
+	if b.isTime {
+		return s.timestamp(b.time)
+	}
+	return toTimestamp(b.timestamp, s.index.signed)
+}
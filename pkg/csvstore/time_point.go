@@ -0,0 +1,11 @@
+package csvstore
+
+import (
+	"time"
+)
+
+// TimePoint is a data point with a time.Time timestamp
+type TimePoint struct {
+	Time   time.Time
+	Record []string
+}
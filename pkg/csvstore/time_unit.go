@@ -0,0 +1,86 @@
+package csvstore
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeUnit is the unit of the timestamps of a store, since the Unix epoch
+type TimeUnit time.Duration
+
+const (
+	// Second is the unit of timestamps in seconds
+	Second = TimeUnit(time.Second)
+
+	// Millisecond is the unit of timestamps in milliseconds
+	Millisecond = TimeUnit(time.Millisecond)
+
+	// Microsecond is the unit of timestamps in microseconds
+	Microsecond = TimeUnit(time.Microsecond)
+
+	// Nanosecond is the unit of timestamps in nanoseconds
+	Nanosecond = TimeUnit(time.Nanosecond)
+)
+
+var timeUnitNames = map[TimeUnit]string{
+	Second:      "s",
+	Millisecond: "ms",
+	Microsecond: "us",
+	Nanosecond:  "ns",
+}
+
+func (u TimeUnit) String() string {
+	name, ok := timeUnitNames[u]
+	if !ok {
+		return time.Duration(u).String()
+	}
+	return name
+}
+
+// MarshalText returns the name of the unit, i.e. s, ms, us or ns
+func (u TimeUnit) MarshalText() ([]byte, error) {
+	name, ok := timeUnitNames[u]
+	if !ok {
+		return nil, fmt.Errorf("invalid time unit: %v", time.Duration(u))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText parses the name of the unit, i.e. s, ms, us or ns
+func (u *TimeUnit) UnmarshalText(text []byte) error {
+	for unit, name := range timeUnitNames {
+		if name == string(text) {
+			*u = unit
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid time unit: %s", text)
+}
+
+// perSecond returns the number of units in a second, using seconds if the
+// unit is not set
+func (u TimeUnit) perSecond() int64 {
+	if u <= 0 {
+		return 1
+	}
+	return int64(time.Second / time.Duration(u))
+}
+
+// fromTime returns the number of units since the Unix epoch, truncating the
+// finer precision
+func (u TimeUnit) fromTime(t time.Time) int64 {
+	perSecond := u.perSecond()
+	return t.Unix()*perSecond + int64(t.Nanosecond())/(int64(time.Second)/perSecond)
+}
+
+// toTime returns the UTC time of the number of units since the Unix epoch
+func (u TimeUnit) toTime(value int64) time.Time {
+	perSecond := u.perSecond()
+	seconds := value / perSecond
+	remainder := value % perSecond
+	if remainder < 0 {
+		seconds--
+		remainder += perSecond
+	}
+	return time.Unix(seconds, remainder*(int64(time.Second)/perSecond)).UTC()
+}
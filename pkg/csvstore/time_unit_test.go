@@ -0,0 +1,79 @@
+package csvstore
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeUnit_perSecond(t *testing.T) {
+	assert.Equal(t, int64(1), TimeUnit(0).perSecond())
+	assert.Equal(t, int64(1), Second.perSecond())
+	assert.Equal(t, int64(1000), Millisecond.perSecond())
+	assert.Equal(t, int64(1000000), Microsecond.perSecond())
+	assert.Equal(t, int64(1000000000), Nanosecond.perSecond())
+}
+
+func TestTimeUnit_Text(t *testing.T) {
+	for unit, name := range map[TimeUnit]string{Second: "s", Millisecond: "ms", Microsecond: "us", Nanosecond: "ns"} {
+		assert.Equal(t, name, unit.String())
+
+		content, err := json.Marshal(unit)
+		assert.Nil(t, err)
+		assert.Equal(t, `"`+name+`"`, string(content))
+
+		var got TimeUnit
+		err = json.Unmarshal(content, &got)
+		assert.Nil(t, err)
+		assert.Equal(t, unit, got)
+	}
+
+	_, err := json.Marshal(TimeUnit(time.Minute))
+	assert.NotNil(t, err)
+
+	var got TimeUnit
+	err = json.Unmarshal([]byte(`"min"`), &got)
+	assert.NotNil(t, err)
+}
+
+func TestTimeUnit_fromTime_toTime(t *testing.T) {
+	tests := []struct {
+		name  string
+		unit  TimeUnit
+		time  time.Time
+		value int64
+	}{
+		{
+			name:  "Should convert seconds",
+			unit:  Second,
+			time:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			value: 1577836800,
+		},
+		{
+			name:  "Should convert milliseconds",
+			unit:  Millisecond,
+			time:  time.Date(2020, 1, 1, 0, 0, 0, 123000000, time.UTC),
+			value: 1577836800123,
+		},
+		{
+			name:  "Should convert microseconds before the epoch",
+			unit:  Microsecond,
+			time:  time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC),
+			value: -500000,
+		},
+		{
+			name:  "Should convert nanoseconds",
+			unit:  Nanosecond,
+			time:  time.Date(1970, 1, 1, 0, 0, 1, 1, time.UTC),
+			value: 1000000001,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.value, tt.unit.fromTime(tt.time))
+			assert.Equal(t, tt.time, tt.unit.toTime(tt.value))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package csvstore
+
+import (
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrBeforeEpoch is the error of timestamps before the Unix epoch, that can't
+// be represented if the timestamps are not signed
+var ErrBeforeEpoch = errors.New("timestamp before the Unix epoch")
+
+// timestampCodec converts the timestamps of the rows of the CSV partition
+// files from and to their internal representation
+type timestampCodec struct {
+	column int
+	layout string
+	unit   TimeUnit
+	signed bool
+}
+
+// parse returns the timestamp of the row, and its other columns
+func (c timestampCodec) parse(record []string) (uint64, []string, error) {
+	if c.column < 0 || c.column >= len(record) {
+		return 0, nil, &PartitionError{Column: c.column, Kind: ErrBadTimestamp, Err: csv.ErrFieldCount}
+	}
+
+	value := record[c.column]
+	timestamp, err := c.parseValue(value)
+	if err != nil {
+		return 0, nil, &PartitionError{Column: c.column, Value: value, Kind: ErrBadTimestamp, Err: err}
+	}
+
+	if c.column == 0 {
+		return timestamp, record[1:], nil
+	}
+
+	rest := make([]string, 0, len(record)-1)
+	rest = append(rest, record[:c.column]...)
+	rest = append(rest, record[c.column+1:]...)
+	return timestamp, rest, nil
+}
+
+func (c timestampCodec) parseValue(value string) (uint64, error) {
+	if len(c.layout) == 0 {
+		if c.signed {
+			signed, err := strconv.ParseInt(value, 10, 64)
+			return FromSigned(signed), err
+		}
+		return strconv.ParseUint(value, 10, 64)
+	}
+
+	t, err := time.Parse(c.layout, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return toTimestamp(c.unit.fromTime(t), c.signed)
+}
+
+// format returns the row with the timestamp and the other columns
+func (c timestampCodec) format(timestamp uint64, record []string) []string {
+	result := make([]string, 0, len(record)+1)
+	if c.column <= len(record) {
+		result = append(result, record[:c.column]...)
+	} else {
+		result = append(result, record...)
+		for len(result) < c.column {
+			result = append(result, "")
+		}
+	}
+
+	result = append(result, c.formatValue(timestamp))
+
+	if c.column < len(record) {
+		result = append(result, record[c.column:]...)
+	}
+	return result
+}
+
+func (c timestampCodec) formatValue(timestamp uint64) string {
+	if len(c.layout) == 0 {
+		if c.signed {
+			return strconv.FormatInt(ToSigned(timestamp), 10)
+		}
+		return strconv.FormatUint(timestamp, 10)
+	}
+
+	return c.unit.toTime(fromTimestamp(timestamp, c.signed)).Format(c.layout)
+}
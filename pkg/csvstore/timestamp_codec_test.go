@@ -0,0 +1,146 @@
+package csvstore
+
+import (
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_timestampCodec_parse(t *testing.T) {
+	tests := []struct {
+		name          string
+		codec         timestampCodec
+		record        []string
+		wantTimestamp uint64
+		wantRecord    []string
+		wantErr       error
+	}{
+		{
+			name:          "Should parse integer timestamp in the first column",
+			record:        []string{"10", "a", "b"},
+			wantTimestamp: 10,
+			wantRecord:    []string{"a", "b"},
+		},
+		{
+			name:          "Should parse integer timestamp in another column",
+			codec:         timestampCodec{column: 1},
+			record:        []string{"a", "10", "b"},
+			wantTimestamp: 10,
+			wantRecord:    []string{"a", "b"},
+		},
+		{
+			name:          "Should parse RFC3339 timestamp in seconds",
+			codec:         timestampCodec{column: 2, layout: time.RFC3339},
+			record:        []string{"a", "b", "2020-01-01T01:00:00+01:00"},
+			wantTimestamp: 1577836800,
+			wantRecord:    []string{"a", "b"},
+		},
+		{
+			name:          "Should parse RFC3339 timestamp in milliseconds",
+			codec:         timestampCodec{layout: time.RFC3339Nano, unit: Millisecond},
+			record:        []string{"2020-01-01T00:00:00.123456Z", "a"},
+			wantTimestamp: 1577836800123,
+			wantRecord:    []string{"a"},
+		},
+		{
+			name:          "Should parse custom layout",
+			codec:         timestampCodec{layout: "2006-01-02 15:04", unit: Nanosecond},
+			record:        []string{"1970-01-01 00:01", "a"},
+			wantTimestamp: 60000000000,
+			wantRecord:    []string{"a"},
+		},
+		{
+			name:    "Should return error if the timestamp is invalid",
+			codec:   timestampCodec{layout: time.RFC3339},
+			record:  []string{"invalid", "a"},
+			wantErr: ErrBadTimestamp,
+		},
+		{
+			name:    "Should return error if the timestamp is before the epoch",
+			codec:   timestampCodec{layout: time.RFC3339},
+			record:  []string{"1969-12-31T23:59:59Z", "a"},
+			wantErr: ErrBeforeEpoch,
+		},
+		{
+			name:    "Should return error if the timestamp column is missing",
+			codec:   timestampCodec{column: 2},
+			record:  []string{"10", "a"},
+			wantErr: csv.ErrFieldCount,
+		},
+		{
+			name:    "Should return error if the timestamp column is negative",
+			codec:   timestampCodec{column: -1},
+			record:  []string{"10", "a"},
+			wantErr: csv.ErrFieldCount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timestamp, record, err := tt.codec.parse(tt.record)
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.True(t, errors.Is(err, ErrBadTimestamp))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantTimestamp, timestamp)
+			assert.Equal(t, tt.wantRecord, record)
+		})
+	}
+}
+
+func Test_timestampCodec_format(t *testing.T) {
+	tests := []struct {
+		name      string
+		codec     timestampCodec
+		timestamp uint64
+		record    []string
+		want      []string
+	}{
+		{
+			name:      "Should format integer timestamp in the first column",
+			timestamp: 10,
+			record:    []string{"a", "b"},
+			want:      []string{"10", "a", "b"},
+		},
+		{
+			name:      "Should format integer timestamp in another column",
+			codec:     timestampCodec{column: 1},
+			timestamp: 10,
+			record:    []string{"a", "b"},
+			want:      []string{"a", "10", "b"},
+		},
+		{
+			name:      "Should format timestamp in the last column",
+			codec:     timestampCodec{column: 2, layout: time.RFC3339},
+			timestamp: 1577836800,
+			record:    []string{"a", "b"},
+			want:      []string{"a", "b", "2020-01-01T00:00:00Z"},
+		},
+		{
+			name:      "Should pad the record if the column is after its end",
+			codec:     timestampCodec{column: 2},
+			timestamp: 10,
+			record:    []string{"a"},
+			want:      []string{"a", "", "10"},
+		},
+		{
+			name:      "Should format timestamp in milliseconds",
+			codec:     timestampCodec{layout: time.RFC3339Nano, unit: Millisecond},
+			timestamp: 1577836800123,
+			record:    []string{"a"},
+			want:      []string{"2020-01-01T00:00:00.123Z", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.codec.format(tt.timestamp, tt.record)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package csvstore
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidTimestampFormat is returned when the timestamp format of the
+// store is not valid
+var ErrInvalidTimestampFormat = errors.New("invalid timestamp format")
+
+// TimestampFormat defines how the timestamps are stored in the CSV partition
+// files
+type TimestampFormat struct {
+	// Column is the index of the timestamp column in the rows
+	Column int
+
+	// Layout is the layout of the timestamps, as defined by time.Parse, e.g.
+	// time.RFC3339; if empty, the timestamps are unsigned integers in the unit
+	// of the store. Timestamps with a layout are written in UTC, and the layout
+	// must keep the precision of the unit, e.g. time.RFC3339Nano.
+	Layout string
+}
+
+// validate returns an error wrapping ErrInvalidTimestampFormat if the format
+// is not valid, or its layout can't keep the precision of the unit
+func (f TimestampFormat) validate(unit TimeUnit) error {
+	if f.Column < 0 {
+		return fmt.Errorf("%w: negative column %d", ErrInvalidTimestampFormat, f.Column)
+	}
+	if len(f.Layout) == 0 {
+		return nil
+	}
+
+	probe := unit.toTime(unit.fromTime(time.Date(2001, 2, 3, 4, 5, 6, 789123456, time.UTC)))
+	parsed, err := time.Parse(f.Layout, probe.Format(f.Layout))
+	if err != nil || !parsed.Equal(probe) {
+		return fmt.Errorf("%w: layout %q can't keep the precision of the unit %v", ErrInvalidTimestampFormat, f.Layout, unit)
+	}
+	return nil
+}
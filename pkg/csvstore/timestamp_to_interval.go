@@ -1,6 +1,17 @@
 package csvstore
 
-func timestampToInterval(timestamp uint64, interval uint64) (from uint64, to uint64) {
+func timestampToInterval(timestamp uint64, interval uint64, signed bool) (from uint64, to uint64) {
+	if signed {
+		// align the intervals to the signed zero
+		value := ToSigned(timestamp)
+		start := value / int64(interval) * int64(interval)
+		if start > value {
+			start -= int64(interval)
+		}
+		from = FromSigned(start)
+		return from, from + interval - 1
+	}
+
 	from = timestamp / interval * interval
 	to = from + interval - 1
 	return from, to
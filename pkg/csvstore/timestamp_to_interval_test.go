@@ -6,6 +6,7 @@ func Test_timestampToInterval(t *testing.T) {
 	type args struct {
 		timestamp uint64
 		interval  uint64
+		signed    bool
 	}
 	tests := []struct {
 		name     string
@@ -31,10 +32,40 @@ func Test_timestampToInterval(t *testing.T) {
 			wantFrom: 23,
 			wantTo:   45,
 		},
+		{
+			name: "From 0 to 9 signed",
+			args: args{
+				timestamp: FromSigned(1),
+				interval:  10,
+				signed:    true,
+			},
+			wantFrom: FromSigned(0),
+			wantTo:   FromSigned(9),
+		},
+		{
+			name: "From -10 to -1 signed",
+			args: args{
+				timestamp: FromSigned(-1),
+				interval:  10,
+				signed:    true,
+			},
+			wantFrom: FromSigned(-10),
+			wantTo:   FromSigned(-1),
+		},
+		{
+			name: "From -20 to -11 signed",
+			args: args{
+				timestamp: FromSigned(-20),
+				interval:  10,
+				signed:    true,
+			},
+			wantFrom: FromSigned(-20),
+			wantTo:   FromSigned(-11),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotFrom, gotTo := timestampToInterval(tt.args.timestamp, tt.args.interval)
+			gotFrom, gotTo := timestampToInterval(tt.args.timestamp, tt.args.interval, tt.args.signed)
 			if gotFrom != tt.wantFrom {
 				t.Errorf("timestampToInterval() gotFrom = %v, want %v", gotFrom, tt.wantFrom)
 			}
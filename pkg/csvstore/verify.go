@@ -0,0 +1,99 @@
+package csvstore
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Verify scans all the partitions of the store, checking their checksum and
+// their content, and reports the ones that are corrupted, truncated or not
+// sorted
+func (s *Store) Verify() (*VerifyReport, error) {
+	names, err := listDatasets(s.dir, s.index.signed)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VerifyReport{
+		Partitions: make([]*PartitionReport, 0, len(names)),
+	}
+	for _, name := range names {
+		partition, err := s.verifyPartition(name)
+		if err != nil {
+			return nil, err
+		}
+		report.Partitions = append(report.Partitions, partition)
+	}
+
+	return report, nil
+}
+
+func (s *Store) verifyPartition(name string) (*PartitionReport, error) {
+	path := s.path(name)
+	report := &PartitionReport{Path: path}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	counter := &countingWriter{writer: ioutil.Discard}
+	_, err = io.Copy(counter, file)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := readPartitionMeta(path)
+	switch {
+	case err != nil || meta.File != filepath.Base(path):
+		report.add(MissingChecksum)
+	case counter.count < meta.Size:
+		report.add(Truncated)
+	case counter.count != meta.Size || counter.crc != meta.CRC32:
+		report.add(Corrupted)
+	}
+
+	if !isColumnar(path) && compressionOf(path) == NoCompression && counter.count > 0 {
+		last := make([]byte, 1)
+		_, err = file.ReadAt(last, counter.count-1)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(last, []byte("\n")) {
+			report.add(Truncated)
+		}
+	}
+
+	from, to, _ := parseDatasetName(name, s.index.signed)
+	var previous uint64
+	err = readPartitionFile(path, readOptions{format: s.fileFormat()}, func(timestamp uint64, _ []string) error {
+		if report.Rows > 0 && timestamp <= previous {
+			report.add(OutOfOrder)
+		}
+		if timestamp < from || timestamp > to {
+			report.add(OutOfRange)
+		}
+		previous = timestamp
+		report.Rows++
+		return nil
+	})
+	if err != nil {
+		report.Err = err
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			report.add(Truncated)
+		} else {
+			report.add(Corrupted)
+		}
+	}
+
+	if meta != nil && report.Rows < meta.Rows && !report.Has(MissingChecksum) {
+		report.add(Truncated)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,23 @@
+package csvstore
+
+// VerifyReport contains the result of the verification of a store
+type VerifyReport struct {
+	// Partitions contains the report of each partition, sorted by interval
+	Partitions []*PartitionReport
+}
+
+// OK returns true if no issue was found in any partition
+func (r *VerifyReport) OK() bool {
+	return len(r.Damaged()) == 0
+}
+
+// Damaged returns the reports of the partitions with issues
+func (r *VerifyReport) Damaged() []*PartitionReport {
+	var damaged []*PartitionReport
+	for _, p := range r.Partitions {
+		if !p.OK() {
+			damaged = append(damaged, p)
+		}
+	}
+	return damaged
+}
@@ -0,0 +1,19 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyReport(t *testing.T) {
+	ok := &PartitionReport{Path: "0_9.csv"}
+	damaged := &PartitionReport{Path: "10_19.csv", Issues: []PartitionIssue{Corrupted}}
+
+	assert.True(t, (&VerifyReport{Partitions: []*PartitionReport{ok}}).OK())
+
+	r := &VerifyReport{Partitions: []*PartitionReport{ok, damaged}}
+
+	assert.False(t, r.OK())
+	assert.Equal(t, []*PartitionReport{damaged}, r.Damaged())
+}
@@ -0,0 +1,185 @@
+package csvstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasdam/go-files-test/pkg/filestest"
+	"github.com/pasdam/go-io-utilx/pkg/ioutilx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Verify(t *testing.T) {
+	type partition struct {
+		name    string
+		points  []Point
+		content string // overwrites the content after the write
+	}
+	tests := []struct {
+		name       string
+		opts       []Option
+		partitions []partition
+		want       []PartitionIssue
+		wantRows   int
+	}{
+		{
+			name: "Should not report issues for valid partitions",
+			partitions: []partition{
+				{name: "0_9.csv", points: []Point{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 2, Record: []string{"b"}}}},
+			},
+			wantRows: 2,
+		},
+		{
+			name: "Should not report issues for valid compressed partitions",
+			opts: []Option{WithCompression(Gzip)},
+			partitions: []partition{
+				{name: "0_9.csv.gz", points: []Point{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 2, Record: []string{"b"}}}},
+			},
+			wantRows: 2,
+		},
+		{
+			name: "Should report missing checksum",
+			partitions: []partition{
+				{name: "0_9.csv", content: "1,a\n2,b\n"},
+			},
+			want:     []PartitionIssue{MissingChecksum},
+			wantRows: 2,
+		},
+		{
+			name: "Should report truncated partition",
+			partitions: []partition{
+				{name: "0_9.csv", points: []Point{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 2, Record: []string{"b"}}}, content: "1,a\n2,"},
+			},
+			want:     []PartitionIssue{Truncated},
+			wantRows: 2,
+		},
+		{
+			name: "Should report truncated compressed partition",
+			opts: []Option{WithCompression(Gzip)},
+			partitions: []partition{
+				{name: "0_9.csv.gz", points: []Point{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 2, Record: []string{"b"}}}, content: "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff"},
+			},
+			want: []PartitionIssue{Truncated},
+		},
+		{
+			name: "Should report corrupted partition",
+			partitions: []partition{
+				{name: "0_9.csv", points: []Point{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 2, Record: []string{"b"}}}, content: "1,a\n2,c\n"},
+			},
+			want:     []PartitionIssue{Corrupted},
+			wantRows: 2,
+		},
+		{
+			name: "Should report partition that can't be parsed",
+			partitions: []partition{
+				{name: "0_9.csv", content: "1,a\ninvalid,b\n"},
+			},
+			want:     []PartitionIssue{MissingChecksum, Corrupted},
+			wantRows: 1,
+		},
+		{
+			name: "Should report out of order and out of range rows",
+			partitions: []partition{
+				{name: "0_9.csv", content: "2,a\n1,b\n12,c\n"},
+			},
+			want:     []PartitionIssue{MissingChecksum, OutOfOrder, OutOfRange},
+			wantRows: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(filestest.TempDir(t), 10, tt.opts...)
+			for _, p := range tt.partitions {
+				if len(p.points) > 0 {
+					_, err := s.AppendBatch(p.points)
+					assert.Nil(t, err)
+				}
+				if len(p.content) > 0 {
+					err := ioutilx.ReaderToFile(strings.NewReader(p.content), filepath.Join(s.dir, p.name))
+					assert.Nil(t, err)
+				}
+			}
+
+			got, err := s.Verify()
+
+			assert.Nil(t, err)
+			assert.Len(t, got.Partitions, 1)
+			assert.Equal(t, filepath.Join(s.dir, tt.partitions[0].name), got.Partitions[0].Path)
+			assert.Equal(t, tt.want, got.Partitions[0].Issues)
+			assert.Equal(t, tt.wantRows, got.Partitions[0].Rows)
+			damaged := false
+			for _, issue := range tt.want {
+				damaged = damaged || issue != MissingChecksum
+			}
+			assert.Equal(t, !damaged, got.OK())
+		})
+	}
+}
+
+func TestStore_Verify_ShouldValidateAppendedPartitions(t *testing.T) {
+	s := NewStore(filestest.TempDir(t), 10)
+	for i := uint64(0); i < 5; i++ {
+		err := s.Append(i, []string{"some-value"})
+		assert.Nil(t, err)
+	}
+
+	got, err := s.Verify()
+
+	assert.Nil(t, err)
+	assert.True(t, got.OK())
+	assert.Equal(t, 5, got.Partitions[0].Rows)
+	meta, err := readPartitionMeta(filepath.Join(s.dir, "0_9.csv"))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, meta.Rows)
+	assert.Equal(t, uint64(0), meta.First)
+	assert.Equal(t, uint64(4), meta.Last)
+}
+
+func TestStore_Verify_ShouldReturnErrorIfFolderDoesNotExist(t *testing.T) {
+	s := NewStore("some-not-existing-folder", 10)
+
+	got, err := s.Verify()
+
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, got)
+}
+
+func TestStore_Verify_ShouldReportDamagedColumnarPartition(t *testing.T) {
+	s := NewStore(filestest.TempDir(t), 10)
+	_, err := s.AppendBatch([]Point{{Timestamp: 1, Record: []string{"a"}}, {Timestamp: 2, Record: []string{"b"}}})
+	assert.Nil(t, err)
+	converted, err := s.CompactBefore(10)
+	assert.Nil(t, err)
+	content, err := ioutil.ReadFile(converted[0])
+	assert.Nil(t, err)
+	// damage the row count
+	content = append([]byte(columnarMagic+"\xff\xff\xff\xff\x7f"), content[len(columnarMagic)+1:]...)
+	err = ioutil.WriteFile(converted[0], content, 0644)
+	assert.Nil(t, err)
+
+	got, err := s.Verify()
+
+	assert.Nil(t, err)
+	assert.False(t, got.OK())
+	assert.Equal(t, []PartitionIssue{Corrupted, Truncated}, got.Partitions[0].Issues)
+}
+
+func TestStore_Verify_ShouldBeOKForPartitionsWithoutMetadata(t *testing.T) {
+	dir := filestest.TempDir(t)
+	err := ioutilx.ReaderToFile(strings.NewReader("1,a\n2,b\n"), filepath.Join(dir, "0_9.csv"))
+	assert.Nil(t, err)
+	err = ioutilx.ReaderToFile(strings.NewReader("11,c\n"), filepath.Join(dir, "10_19.csv"))
+	assert.Nil(t, err)
+	s := NewStore(dir, 10)
+
+	got, err := s.Verify()
+
+	assert.Nil(t, err)
+	assert.True(t, got.OK())
+	assert.Empty(t, got.Damaged())
+	assert.Equal(t, []PartitionIssue{MissingChecksum}, got.Partitions[0].Issues)
+	assert.Equal(t, []PartitionIssue{MissingChecksum}, got.Partitions[1].Issues)
+}
@@ -0,0 +1,10 @@
+package csvstore
+
+// WithColdAge enables the columnar tier: the partitions ending more than age
+// before the last point in the store are converted into the columnar format
+// by Compact
+func WithColdAge(age uint64) Option {
+	return func(s *Store) {
+		s.coldAge = age
+	}
+}
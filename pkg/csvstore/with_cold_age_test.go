@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithColdAge(t *testing.T) {
+	s := &Store{}
+
+	WithColdAge(100)(s)
+
+	assert.Equal(t, uint64(100), s.coldAge)
+}
@@ -0,0 +1,10 @@
+package csvstore
+
+// WithCompression sets the codec used to compress the partition files
+// written by the store. Partitions stored with a different codec can still be
+// read, and are converted when rewritten.
+func WithCompression(compression Compression) Option {
+	return func(s *Store) {
+		s.compression = compression
+	}
+}
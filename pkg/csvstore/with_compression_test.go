@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCompression(t *testing.T) {
+	s := &Store{}
+
+	WithCompression(Zstd)(s)
+
+	assert.Equal(t, Zstd, s.compression)
+}
@@ -0,0 +1,9 @@
+package csvstore
+
+// WithConflictPolicy sets the policy used to resolve data points with the
+// same timestamp of existing ones
+func WithConflictPolicy(policy ConflictPolicy) Option {
+	return func(s *Store) {
+		s.policy = policy
+	}
+}
@@ -0,0 +1,17 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithConflictPolicy(t *testing.T) {
+	s := &Store{}
+
+	WithConflictPolicy(KeepOnConflict)(s)
+
+	got, err := s.policy(1, []string{"some-existing-value"}, []string{"some-incoming-value"})
+	assert.Nil(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,9 @@
+package csvstore
+
+// WithDialect sets the format of the CSV partition files, used both to read
+// and write them
+func WithDialect(dialect Dialect) Option {
+	return func(s *Store) {
+		s.dialect = dialect
+	}
+}
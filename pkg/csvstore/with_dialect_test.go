@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDialect(t *testing.T) {
+	s := &Store{}
+
+	WithDialect(Dialect{Delimiter: ';', UseCRLF: true})(s)
+
+	assert.Equal(t, Dialect{Delimiter: ';', UseCRLF: true}, s.dialect)
+}
@@ -0,0 +1,9 @@
+package csvstore
+
+// WithMalformedRows sets how the reads handle the rows that can't be parsed;
+// by default the read is aborted
+func WithMalformedRows(mode MalformedRows) Option {
+	return func(s *Store) {
+		s.malformed = mode
+	}
+}
@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMalformedRows(t *testing.T) {
+	s := &Store{}
+
+	WithMalformedRows(CollectMalformed)(s)
+
+	assert.Equal(t, CollectMalformed, s.malformed)
+}
@@ -0,0 +1,9 @@
+package csvstore
+
+// WithRetention sets the retention of the store: the partitions ending more
+// than age before the last point in the store are deleted by Expire
+func WithRetention(age uint64) Option {
+	return func(s *Store) {
+		s.retention = age
+	}
+}
@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRetention(t *testing.T) {
+	s := &Store{}
+
+	WithRetention(100)(s)
+
+	assert.Equal(t, uint64(100), s.retention)
+}
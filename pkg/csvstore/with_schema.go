@@ -0,0 +1,9 @@
+package csvstore
+
+// WithSchema sets the columns of the records, excluding the timestamp, used to
+// select them by name in the queries, and to compare their values
+func WithSchema(columns ...Column) Option {
+	return func(s *Store) {
+		s.schema = columns
+	}
+}
@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSchema(t *testing.T) {
+	s := &Store{}
+
+	WithSchema(Column{Name: "a"}, Column{Name: "b", Type: IntColumn})(s)
+
+	assert.Equal(t, Schema{{Name: "a"}, {Name: "b", Type: IntColumn}}, s.schema)
+}
@@ -0,0 +1,11 @@
+package csvstore
+
+// WithSignedTimestamps enables the signed mode: the timestamps are int64,
+// converted to the uint64 ones used by the API with FromSigned and ToSigned,
+// so that they can be before the Unix epoch. The partition files are named
+// after the signed intervals.
+func WithSignedTimestamps() Option {
+	return func(s *Store) {
+		s.index.signed = true
+	}
+}
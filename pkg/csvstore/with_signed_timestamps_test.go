@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSignedTimestamps(t *testing.T) {
+	s := &Store{}
+
+	WithSignedTimestamps()(s)
+
+	assert.True(t, s.index.signed)
+}
@@ -0,0 +1,10 @@
+package csvstore
+
+// WithStrictReads enables the validation of the partitions on read: rows with
+// a timestamp outside the interval of their partition, or not greater than the
+// previous one, make the read fail with a PartitionError
+func WithStrictReads() Option {
+	return func(s *Store) {
+		s.strict = true
+	}
+}
@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithStrictReads(t *testing.T) {
+	s := &Store{}
+
+	WithStrictReads()(s)
+
+	assert.True(t, s.strict)
+}
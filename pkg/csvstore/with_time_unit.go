@@ -0,0 +1,9 @@
+package csvstore
+
+// WithTimeUnit sets the unit of the timestamps of the store, used to convert
+// the ones with a layout; by default it is Second
+func WithTimeUnit(unit TimeUnit) Option {
+	return func(s *Store) {
+		s.unit = unit
+	}
+}
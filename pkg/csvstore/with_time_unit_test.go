@@ -0,0 +1,15 @@
+package csvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeUnit(t *testing.T) {
+	s := &Store{}
+
+	WithTimeUnit(Millisecond)(s)
+
+	assert.Equal(t, Millisecond, s.unit)
+}
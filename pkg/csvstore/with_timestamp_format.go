@@ -0,0 +1,10 @@
+package csvstore
+
+// WithTimestampFormat sets the position and the encoding of the timestamps in
+// the CSV partition files; by default they are unsigned integers in the first
+// column
+func WithTimestampFormat(format TimestampFormat) Option {
+	return func(s *Store) {
+		s.timestamps = format
+	}
+}
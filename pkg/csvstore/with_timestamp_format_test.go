@@ -0,0 +1,16 @@
+package csvstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimestampFormat(t *testing.T) {
+	s := &Store{}
+
+	WithTimestampFormat(TimestampFormat{Column: 2, Layout: time.RFC3339})(s)
+
+	assert.Equal(t, TimestampFormat{Column: 2, Layout: time.RFC3339}, s.timestamps)
+}
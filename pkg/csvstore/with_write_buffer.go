@@ -0,0 +1,16 @@
+package csvstore
+
+import (
+	"time"
+)
+
+// WithWriteBuffer enables the in-memory write buffer: the points stored with
+// StorePoints are kept in memory and persisted when the buffer contains at
+// least size points (if size is greater than 0), every flushInterval (if
+// greater than 0), or when Flush or Close are called. LoadPoints and LastPoint
+// return both buffered and persisted points.
+func WithWriteBuffer(size int, flushInterval time.Duration) Option {
+	return func(s *Store) {
+		s.buffer = newWriteBuffer(size, flushInterval)
+	}
+}
@@ -0,0 +1,18 @@
+package csvstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithWriteBuffer(t *testing.T) {
+	s := &Store{}
+
+	WithWriteBuffer(10, time.Second)(s)
+
+	assert.NotNil(t, s.buffer)
+	assert.Equal(t, 10, s.buffer.size)
+	assert.Equal(t, time.Second, s.buffer.interval)
+}
@@ -0,0 +1,185 @@
+package csvstore
+
+import (
+	"sync"
+	"time"
+)
+
+// writeBuffer keeps in memory the points to store, sorted by timestamp, until
+// they are flushed to disk
+type writeBuffer struct {
+	mutex    sync.Mutex
+	points   dataPointList
+	flushing dataPointList
+	size     int
+	interval time.Duration
+	err      error
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func newWriteBuffer(size int, interval time.Duration) *writeBuffer {
+	return &writeBuffer{
+		size:     size,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// add merges the sorted incoming points into the buffer, and returns true if
+// the buffer is full and should be flushed
+func (b *writeBuffer) add(incoming dataPointList, policy ConflictPolicy) (InsertStats, bool, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var stats InsertStats
+	if len(incoming) == 0 {
+		return stats, false, nil
+	}
+
+	last := len(b.points) - 1
+	if last < 0 || b.points[last].timestamp < incoming[0].timestamp {
+		b.points = append(b.points, incoming...)
+		stats.Inserted = len(incoming)
+
+	} else {
+		merged, mergeStats, err := mergePoints(b.points, incoming, policy)
+		if err != nil {
+			return InsertStats{}, false, err
+		}
+		b.points = merged
+		stats = mergeStats
+	}
+
+	return stats, b.size > 0 && len(b.points) >= b.size, nil
+}
+
+// unbuffered returns the sorted points whose timestamp is not in the buffer
+func (b *writeBuffer) unbuffered(points dataPointList) dataPointList {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var result dataPointList
+	i := 0
+	for _, point := range points {
+		for i < len(b.points) && b.points[i].timestamp < point.timestamp {
+			i++
+		}
+		if i >= len(b.points) || b.points[i].timestamp != point.timestamp {
+			result = append(result, point)
+		}
+	}
+
+	return result
+}
+
+// startFlush returns the buffered points, that will be visible to readers
+// until endFlush is called
+func (b *writeBuffer) startFlush() dataPointList {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.flushing = b.points
+	b.points = nil
+
+	return b.flushing
+}
+
+// endFlush releases the points being flushed, or restores them in the buffer
+// if the flush failed and can be retried
+func (b *writeBuffer) endFlush(restore bool, policy ConflictPolicy) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if restore {
+		merged, _, err := mergePoints(b.flushing, b.points, policy)
+		if err != nil {
+			merged, _, _ = mergePoints(b.flushing, b.points, ReplaceOnConflict)
+		}
+		b.points = merged
+	}
+	b.flushing = nil
+}
+
+// snapshot returns a copy of the buffered points with timestamp between from
+// and to
+func (b *writeBuffer) snapshot(from uint64, to uint64, policy ConflictPolicy) dataPointList {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	points := b.points.between(from, to)
+	flushing := b.flushing.between(from, to)
+	if len(flushing) == 0 {
+		return append(dataPointList(nil), points...)
+	}
+
+	merged, _, err := mergePoints(flushing, points, policy)
+	if err != nil {
+		merged, _, _ = mergePoints(flushing, points, ReplaceOnConflict)
+	}
+	return merged
+}
+
+// last returns the buffered point with the greatest timestamp, nil if the
+// buffer is empty
+func (b *writeBuffer) last(policy ConflictPolicy) *dataPoint {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var last *dataPoint
+	if len(b.flushing) > 0 {
+		last = b.flushing[len(b.flushing)-1]
+	}
+	if len(b.points) > 0 {
+		point := b.points[len(b.points)-1]
+		if last == nil || point.timestamp > last.timestamp {
+			last = point
+		} else if point.timestamp == last.timestamp {
+			last = &dataPoint{
+				timestamp: point.timestamp,
+				record:    resolveConflict(policy, point.timestamp, last.record, point.record),
+			}
+		}
+	}
+
+	return last
+}
+
+// run calls flush every interval, until close is called
+func (b *writeBuffer) run(flush func() error) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := flush()
+			if err != nil {
+				b.mutex.Lock()
+				b.err = err
+				b.mutex.Unlock()
+			}
+
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// close stops the periodic flush, if running, and returns the last error
+// raised by it
+func (b *writeBuffer) close() error {
+	if b.interval > 0 {
+		b.stopOnce.Do(func() { close(b.stop) })
+		<-b.done
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.err
+}
@@ -0,0 +1,240 @@
+package csvstore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeBuffer_add(t *testing.T) {
+	type fields struct {
+		points dataPointList
+		size   int
+	}
+	type args struct {
+		incoming dataPointList
+		policy   ConflictPolicy
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		want      dataPointList
+		wantStats InsertStats
+		wantFull  bool
+		wantErr   error
+	}{
+		{
+			name: "Should append points after the buffered ones",
+			fields: fields{
+				points: dataPointList{{timestamp: 1}},
+				size:   10,
+			},
+			args: args{
+				incoming: dataPointList{{timestamp: 2}, {timestamp: 3}},
+			},
+			want:      dataPointList{{timestamp: 1}, {timestamp: 2}, {timestamp: 3}},
+			wantStats: InsertStats{Inserted: 2},
+		},
+		{
+			name: "Should merge points with the buffered ones",
+			fields: fields{
+				points: dataPointList{{timestamp: 1, record: []string{"some-value-at-1"}}, {timestamp: 3}},
+			},
+			args: args{
+				incoming: dataPointList{{timestamp: 1, record: []string{"some-other-value-at-1"}}, {timestamp: 2}},
+			},
+			want:      dataPointList{{timestamp: 1, record: []string{"some-other-value-at-1"}}, {timestamp: 2}, {timestamp: 3}},
+			wantStats: InsertStats{Inserted: 1, Replaced: 1},
+		},
+		{
+			name: "Should report buffer is full",
+			fields: fields{
+				points: dataPointList{{timestamp: 1}},
+				size:   2,
+			},
+			args: args{
+				incoming: dataPointList{{timestamp: 2}},
+			},
+			want:      dataPointList{{timestamp: 1}, {timestamp: 2}},
+			wantStats: InsertStats{Inserted: 1},
+			wantFull:  true,
+		},
+		{
+			name: "Should not change the buffer if policy raises an error",
+			fields: fields{
+				points: dataPointList{{timestamp: 1}, {timestamp: 3}},
+			},
+			args: args{
+				incoming: dataPointList{{timestamp: 2}, {timestamp: 3}},
+				policy:   ErrorOnConflict,
+			},
+			want:    dataPointList{{timestamp: 1}, {timestamp: 3}},
+			wantErr: ErrConflict,
+		},
+		{
+			name: "Should ignore empty list",
+			fields: fields{
+				points: dataPointList{{timestamp: 1}},
+			},
+			args: args{
+				incoming: dataPointList{},
+			},
+			want: dataPointList{{timestamp: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newWriteBuffer(tt.fields.size, 0)
+			b.points = tt.fields.points
+
+			stats, full, err := b.add(tt.args.incoming, tt.args.policy)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantStats, stats)
+			assert.Equal(t, tt.wantFull, full)
+			assert.Equal(t, tt.want, b.points)
+		})
+	}
+}
+
+func Test_writeBuffer_flush(t *testing.T) {
+	tests := []struct {
+		name       string
+		restore    bool
+		wantPoints dataPointList
+	}{
+		{
+			name:       "Should release points if flush succeeded",
+			restore:    false,
+			wantPoints: dataPointList{{timestamp: 3}},
+		},
+		{
+			name:       "Should restore points if flush failed",
+			restore:    true,
+			wantPoints: dataPointList{{timestamp: 1}, {timestamp: 2}, {timestamp: 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newWriteBuffer(0, 0)
+			b.points = dataPointList{{timestamp: 1}, {timestamp: 2}}
+
+			got := b.startFlush()
+
+			assert.Equal(t, dataPointList{{timestamp: 1}, {timestamp: 2}}, got)
+			assert.Nil(t, b.points)
+			_, _, err := b.add(dataPointList{{timestamp: 3}}, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, dataPointList{{timestamp: 1}, {timestamp: 2}, {timestamp: 3}}, b.snapshot(0, 10, nil))
+
+			b.endFlush(tt.restore, nil)
+
+			assert.Nil(t, b.flushing)
+			assert.Equal(t, tt.wantPoints, b.points)
+		})
+	}
+}
+
+func Test_writeBuffer_snapshot(t *testing.T) {
+	b := newWriteBuffer(0, 0)
+	b.flushing = dataPointList{
+		{timestamp: 1, record: []string{"some-flushing-value-at-1"}},
+		{timestamp: 3, record: []string{"some-flushing-value-at-3"}},
+	}
+	b.points = dataPointList{
+		{timestamp: 2, record: []string{"some-value-at-2"}},
+		{timestamp: 3, record: []string{"some-value-at-3"}},
+		{timestamp: 4, record: []string{"some-value-at-4"}},
+	}
+
+	got := b.snapshot(2, 3, nil)
+
+	assert.Equal(t, dataPointList{
+		{timestamp: 2, record: []string{"some-value-at-2"}},
+		{timestamp: 3, record: []string{"some-value-at-3"}},
+	}, got)
+}
+
+func Test_writeBuffer_last(t *testing.T) {
+	type fields struct {
+		points   dataPointList
+		flushing dataPointList
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   *dataPoint
+	}{
+		{
+			name: "Should return nil if buffer is empty",
+			want: nil,
+		},
+		{
+			name: "Should return last buffered point",
+			fields: fields{
+				points:   dataPointList{{timestamp: 1}, {timestamp: 4}},
+				flushing: dataPointList{{timestamp: 3}},
+			},
+			want: &dataPoint{timestamp: 4},
+		},
+		{
+			name: "Should return last point being flushed",
+			fields: fields{
+				points:   dataPointList{{timestamp: 1}},
+				flushing: dataPointList{{timestamp: 3}},
+			},
+			want: &dataPoint{timestamp: 3},
+		},
+		{
+			name: "Should resolve conflict between points being flushed and buffered ones",
+			fields: fields{
+				points:   dataPointList{{timestamp: 3, record: []string{"some-value-at-3"}}},
+				flushing: dataPointList{{timestamp: 3, record: []string{"some-flushing-value-at-3"}}},
+			},
+			want: &dataPoint{timestamp: 3, record: []string{"some-value-at-3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newWriteBuffer(0, 0)
+			b.points = tt.fields.points
+			b.flushing = tt.fields.flushing
+
+			got := b.last(nil)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_writeBuffer_run(t *testing.T) {
+	b := newWriteBuffer(0, time.Millisecond)
+	calls := make(chan struct{}, 10)
+	go b.run(func() error {
+		select {
+		case calls <- struct{}{}:
+		default:
+		}
+		return errors.New("some-flush-error")
+	})
+
+	<-calls
+	<-calls
+	err := b.close()
+
+	assert.Equal(t, errors.New("some-flush-error"), err)
+	err = b.close()
+	assert.Equal(t, errors.New("some-flush-error"), err)
+}
+
+func Test_writeBuffer_unbuffered(t *testing.T) {
+	b := newWriteBuffer(0, 0)
+	b.points = dataPointList{{timestamp: 2}, {timestamp: 4}}
+
+	got := b.unbuffered(dataPointList{{timestamp: 1}, {timestamp: 2}, {timestamp: 3}, {timestamp: 5}})
+
+	assert.Equal(t, dataPointList{{timestamp: 1}, {timestamp: 3}, {timestamp: 5}}, got)
+}
@@ -1,13 +1,16 @@
 package csvstore
 
 import (
-	"encoding/csv"
+	"io"
 	"os"
 	"path/filepath"
-	"strconv"
 )
 
-func writeDataset(ds *dataset) error {
+// tempExtension is appended to the path of the partition files while they are
+// rewritten
+const tempExtension = ".tmp"
+
+func writeDataset(ds *dataset) (int64, error) {
 	parent := filepath.Dir(ds.path)
 	_, err := os.Stat(parent)
 	if err != nil {
@@ -15,32 +18,100 @@ func writeDataset(ds *dataset) error {
 			// create
 			err = os.MkdirAll(parent, os.ModePerm)
 			if err != nil {
-				return err
+				return 0, err
 			}
 		} else {
-			return err
+			return 0, err
+		}
+	}
+
+	meta := &partitionMeta{File: filepath.Base(ds.path), HasStats: true}
+	if ds.append {
+		meta, err = currentPartitionMeta(ds.path, ds.format)
+		if err != nil {
+			return 0, err
 		}
 	}
 
-	file, err := os.Create(ds.path)
+	// rewritten files are written to a temporary file, renamed once complete,
+	// so that a failure doesn't leave a partial partition
+	var file *os.File
+	if ds.append {
+		file, err = os.OpenFile(ds.path, os.O_APPEND|os.O_WRONLY, 0)
+	} else {
+		file, err = os.Create(ds.path + tempExtension)
+	}
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	count, err := writeDatasetContent(ds, file, meta)
+	if err != nil {
+		if !ds.append {
+			os.Remove(file.Name())
+		}
+		return 0, err
+	}
+
+	if !ds.append {
+		err = file.Close()
+		if err == nil {
+			err = os.Rename(file.Name(), ds.path)
+		}
+		if err != nil {
+			os.Remove(file.Name())
+			return 0, err
+		}
+	}
 
-	for i := 0; i < ds.points.Length(); i++ {
-		record := make([]string, 0, len(ds.points[i].record)+1)
-		record = append(record, strconv.FormatUint(ds.points[i].timestamp, 10))
-		record = append(record, ds.points[i].record...)
+	err = writePartitionMeta(ds.path, meta)
+	if err != nil {
+		return 0, err
+	}
 
-		err = writer.Write(record)
+	if len(ds.replaces) > 0 && ds.replaces != ds.path {
+		err = os.Remove(ds.replaces)
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
 
-	return nil
+	return count, nil
+}
+
+// writeDatasetContent writes the points of the dataset to the file, updating
+// the partition metadata, and returns the number of bytes written
+func writeDatasetContent(ds *dataset, file io.Writer, meta *partitionMeta) (int64, error) {
+
+	counter := &countingWriter{writer: file, crc: meta.CRC32}
+	compressed, err := compressionOf(ds.path).newWriter(counter)
+	if err != nil {
+		return 0, err
+	}
+	writer := ds.format.dialect.newWriter(compressed)
+
+	for i := 0; i < ds.points.Length(); i++ {
+		err = writer.Write(ds.format.timestamps.format(ds.points[i].timestamp, ds.points[i].record))
+		if err != nil {
+			return 0, err
+		}
+		meta.add(ds.points[i].timestamp, ds.points[i].record)
+	}
+
+	writer.Flush()
+	err = writer.Error()
+	if err != nil {
+		return 0, err
+	}
+
+	err = compressed.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	meta.Size += counter.count
+	meta.CRC32 = counter.crc
+
+	return counter.count, nil
 }
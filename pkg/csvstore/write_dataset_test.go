@@ -105,6 +105,22 @@ func Test_writeDataset(t *testing.T) {
 			},
 			expectedContent: "1,some-existing-file-value-at-1\n",
 		},
+		{
+			name: "Should append to file if requested",
+			mocks: mocks{
+				fileContent: "0,some-existing-file-value-at-0\n",
+			},
+			args: args{
+				ds: &dataset{
+					path: filestest.TempFile(t, "some-appended-file-path"),
+					points: dataPointList{
+						{timestamp: 1, record: []string{"some-appended-file-value-at-1"}},
+					},
+					append: true,
+				},
+			},
+			expectedContent: "1,some-appended-file-value-at-1\n",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -119,7 +135,7 @@ func Test_writeDataset(t *testing.T) {
 			}
 			if tt.mocks.createErr != nil {
 				wantErr = tt.mocks.createErr
-				mockit.MockFunc(t, os.Create).With(tt.args.ds.path).Return(nil, wantErr)
+				mockit.MockFunc(t, os.Create).With(tt.args.ds.path+tempExtension).Return(nil, wantErr)
 			}
 			if tt.mocks.writeErr != nil {
 				wantErr = tt.mocks.writeErr
@@ -133,11 +149,16 @@ func Test_writeDataset(t *testing.T) {
 				}
 			}
 
-			err := writeDataset(tt.args.ds)
+			got, err := writeDataset(tt.args.ds)
 
 			assert.Equal(t, wantErr, err)
+			assert.Equal(t, int64(len(tt.expectedContent)), got)
 			if len(tt.expectedContent) > 0 {
-				filestest.FileExistsWithContent(t, tt.args.ds.path, tt.expectedContent)
+				if tt.args.ds.append {
+					filestest.FileExistsWithContent(t, tt.args.ds.path, tt.mocks.fileContent+tt.expectedContent)
+				} else {
+					filestest.FileExistsWithContent(t, tt.args.ds.path, tt.expectedContent)
+				}
 			}
 		})
 	}
@@ -1,11 +1,31 @@
 package csvstore
 
-func writeDatasets(datasets map[uint64]*dataset) error {
-	for _, ds := range datasets {
-		err := writeDataset(ds)
+import (
+	"sort"
+)
+
+func writeDatasets(datasets map[uint64]*dataset) ([]PartitionWriteResult, error) {
+	keys := make([]uint64, 0, len(datasets))
+	for key := range datasets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	results := make([]PartitionWriteResult, 0, len(datasets))
+	for _, key := range keys {
+		ds := datasets[key]
+		bytes, err := writeDataset(ds)
 		if err != nil {
-			return err
+			return nil, err
 		}
+
+		results = append(results, PartitionWriteResult{
+			InsertStats: ds.stats,
+			Path:        ds.path,
+			Appended:    ds.append,
+			Rows:        ds.points.Length(),
+			Bytes:       bytes,
+		})
 	}
-	return nil
+	return results, nil
 }
@@ -85,14 +85,14 @@ func Test_writeDatasets(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			wantErr := tt.mocks.writeErr
 			if tt.mocks.writeErr != nil {
-				mockit.MockFunc(t, writeDataset).With(tt.args.datasets[0]).Return(wantErr)
+				mockit.MockFunc(t, writeDataset).With(tt.args.datasets[0]).Return(int64(0), wantErr)
 			}
 			datasetsMap := make(map[uint64]*dataset)
 			for _, ds := range tt.args.datasets {
 				datasetsMap[ds.points[0].timestamp] = ds
 			}
 
-			err := writeDatasets(datasetsMap)
+			got, err := writeDatasets(datasetsMap)
 
 			assert.Equal(t, wantErr, err)
 			for i := 0; i < len(tt.args.datasets); i++ {
@@ -100,6 +100,9 @@ func Test_writeDatasets(t *testing.T) {
 					assert.NoFileExists(t, tt.args.datasets[i].path)
 				} else {
 					filestest.FileExistsWithContent(t, tt.args.datasets[i].path, tt.expectedContents[i])
+					assert.Equal(t, tt.args.datasets[i].path, got[i].Path)
+					assert.Equal(t, 1, got[i].Rows)
+					assert.Equal(t, int64(len(tt.expectedContents[i])), got[i].Bytes)
 				}
 			}
 		})
@@ -0,0 +1,23 @@
+package csvstore
+
+import (
+	"time"
+)
+
+// WriteResult contains the statistics of a write operation
+type WriteResult struct {
+	InsertStats
+
+	// Buffered contains the statistics of the points added to the write
+	// buffer, if enabled
+	Buffered InsertStats
+
+	// Partitions contains the statistics of each partition file written
+	Partitions []PartitionWriteResult
+
+	// Bytes is the total number of bytes written
+	Bytes int64
+
+	// Duration is the time spent to store the points
+	Duration time.Duration
+}